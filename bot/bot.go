@@ -1,8 +1,11 @@
 package bot
 
 import (
+	"casper/export"
+	"casper/models"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -14,10 +17,25 @@ type commandHandler = func(
 	*gorm.DB,
 )
 
+// componentHandler handles interactions with message components such as
+// buttons. Components are routed by the part of their custom ID before the
+// first separator, the rest is for the handler to interpret.
+type componentHandler = func(
+	*discordgo.InteractionCreate,
+	*gorm.DB,
+)
+
+const customIDSeparator = ":"
+
+// guildOnly is the DM permission of commands that only make sense in a guild.
+// Only /meatball-mydata, which covers every guild, can be used in DMs.
+var guildOnly = false
+
 var botCommands = []*discordgo.ApplicationCommand{
 	{
-		Name:        "meatball",
-		Description: "Looks up a meatball day in the meatball database.",
+		Name:         "meatball",
+		Description:  "Looks up a meatball day in the meatball database.",
+		DMPermission: &guildOnly,
 		Options: []*discordgo.ApplicationCommandOption{
 			{
 				Type:        discordgo.ApplicationCommandOptionUser,
@@ -27,25 +45,109 @@ var botCommands = []*discordgo.ApplicationCommand{
 			},
 		},
 	}, {
-		Name:        "meatball-save",
-		Description: "Saves your meatball day to the meatball database.",
+		Name:         "meatball-save",
+		Description:  "Saves your meatball day to the meatball database.",
+		DMPermission: &guildOnly,
 		Options: []*discordgo.ApplicationCommandOption{
 			{
 				Type: discordgo.ApplicationCommandOptionString,
 				Name: "meatball-day",
 				Description: fmt.Sprintf(
-					"Your meatball day (format: %v)",
-					MeatballDayFormat,
+					"Your meatball day, e.g. %v, 2 Jan or January 2nd",
+					MeatballDayExample,
 				),
 				Required: true,
 			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "year",
+				Description: "The year you were born, if you'd like your age to be known.",
+				Required:    false,
+			},
+		},
+	}, {
+		Name:         "meatball-age",
+		Description:  "Sets whether your age may be shown and announced.",
+		DMPermission: &guildOnly,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "show",
+				Description: "Whether to show your age.",
+				Required:    true,
+			},
+		},
+	}, {
+		Name:         "meatball-forget",
+		Description:  "Removes your meatball day from the meatball database.",
+		DMPermission: &guildOnly,
+	}, {
+		Name:        "meatball-mydata",
+		Description: "Gets or erases everything casper knows about you.",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "export",
+				Description: "Sends you a file of everything casper stores about you in every server.",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "erase",
+				Description: "Permanently erases everything casper stores about you in every server.",
+			},
+		},
+	}, {
+		Name:         "meatball-zone",
+		Description:  "Sets the time zone your meatball day is celebrated in.",
+		DMPermission: &guildOnly,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "zone",
+				Description: "An IANA time zone name, e.g. Europe/London.",
+				Required:    true,
+			},
+		},
+	}, {
+		Name:         "meatball-private",
+		Description:  "Sets whether your meatball day is left out of calendar exports.",
+		DMPermission: &guildOnly,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "hidden",
+				Description: "Whether to leave your meatball day out.",
+				Required:    true,
+			},
+		},
+	}, {
+		Name:         "meatball-follow",
+		Description:  "Get a DM reminder before someone's meatball day.",
+		DMPermission: &guildOnly,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "user",
+				Description: "The user whose meatball day you want reminding of.",
+				Required:    true,
+			},
 		},
 	}, {
-		Name:        "meatball-forget",
-		Description: "Removes your meatball day from the meatball database.",
+		Name:         "meatball-unfollow",
+		Description:  "Stop getting DM reminders before someone's meatball day.",
+		DMPermission: &guildOnly,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "user",
+				Description: "The user whose meatball day you no longer want reminding of.",
+				Required:    true,
+			},
+		},
 	}, {
-		Name:        "meatball-role",
-		Description: "Sets the role to apply on users' meatball days.",
+		Name:         "meatball-role",
+		Description:  "Sets the role to apply on users' meatball days.",
+		DMPermission: &guildOnly,
 		Options: []*discordgo.ApplicationCommandOption{
 			{
 				Type:        discordgo.ApplicationCommandOptionRole,
@@ -55,8 +157,9 @@ var botCommands = []*discordgo.ApplicationCommand{
 			},
 		},
 	}, {
-		Name:        "meatball-chan",
-		Description: "Sets the channel to use for announcements.",
+		Name:         "meatball-chan",
+		Description:  "Sets the channel to use for announcements.",
+		DMPermission: &guildOnly,
 		Options: []*discordgo.ApplicationCommandOption{
 			{
 				Type:        discordgo.ApplicationCommandOptionChannel,
@@ -66,8 +169,408 @@ var botCommands = []*discordgo.ApplicationCommand{
 			},
 		},
 	}, {
-		Name:        "meatball-next",
-		Description: "Gets the next occurring meatball day.",
+		Name:         "meatball-settings",
+		Description:  "Changes how casper works in this server.",
+		DMPermission: &guildOnly,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "zone",
+				Description: "Sets the default time zone for users who haven't set their own.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "zone",
+						Description: "An IANA time zone name, e.g. Europe/London.",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "missed",
+				Description: "Sets what to do about meatball days missed while I was offline.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "policy",
+						Description: "Whether to announce missed meatball days late or skip them.",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Announce belatedly", Value: models.MissedDayBelated},
+							{Name: "Skip", Value: models.MissedDaySkip},
+						},
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "leap",
+				Description: "Sets when to celebrate 29th February meatball days in non-leap years.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "policy",
+						Description: "The day to celebrate on instead, if any.",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "28th February", Value: models.LeapDayFebruary28},
+							{Name: "1st March", Value: models.LeapDayMarch1},
+							{Name: "Skip", Value: models.LeapDaySkip},
+						},
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "date-order",
+				Description: "Sets how numeric dates like 2/1 given to /meatball-save are read.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "order",
+						Description: "Whether the month or the day comes first.",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Month first (2/1 is 1st February)", Value: models.DateOrderMonthDay},
+							{Name: "Day first (2/1 is 2nd January)", Value: models.DateOrderDayMonth},
+						},
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "language",
+				Description: "Sets the language casper speaks in this server.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "language",
+						Description: "The language to use. Replies follow each user's own Discord language if casper speaks it.",
+						Required:    true,
+						Choices:     languageChoices(),
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Name:        "milestone",
+				Description: "Manages special announcements for milestone ages.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "set",
+						Description: "Sets the announcement to use when someone reaches an age.",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:        discordgo.ApplicationCommandOptionInteger,
+								Name:        "age",
+								Description: "The milestone age.",
+								Required:    true,
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionString,
+								Name:        "template",
+								Description: "The announcement. {mention}, {username}, {age}, {date} and {server} are filled in for you.",
+								Required:    true,
+							},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "remove",
+						Description: "Removes the announcement for an age.",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:        discordgo.ApplicationCommandOptionInteger,
+								Name:        "age",
+								Description: "The milestone age.",
+								Required:    true,
+							},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "list",
+						Description: "Lists the milestone announcements.",
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Name:        "announcement",
+				Description: "Manages the announcements picked from at random on meatball days.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "add",
+						Description: "Adds an announcement.",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:        discordgo.ApplicationCommandOptionString,
+								Name:        "template",
+								Description: "The announcement. {mention}, {username}, {age}, {date} and {server} are filled in for you.",
+								Required:    true,
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionBoolean,
+								Name:        "combined",
+								Description: "Use this announcement for several people at once, if they're announced together.",
+								Required:    false,
+							},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "remove",
+						Description: "Removes an announcement.",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:        discordgo.ApplicationCommandOptionInteger,
+								Name:        "id",
+								Description: "The announcement's ID, as shown by list.",
+								Required:    true,
+							},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "list",
+						Description: "Lists the announcements.",
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "style",
+				Description: "Sets how meatball days are announced.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "style",
+						Description: "Whether to announce meatball days in a plain message or an embed.",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Plain message", Value: models.AnnouncementPlain},
+							{Name: "Embed with avatar", Value: models.AnnouncementEmbed},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "color",
+						Description: "The embed's color as a hex code, e.g. #e67e22, or \"default\".",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "image",
+						Description: "A link to an image to show in the embed, or \"none\".",
+						Required:    false,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "combine",
+				Description: "Sets whether people who share a meatball day are announced together.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "combine",
+						Description: "True to announce everyone in one message, false for a message each.",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "wishes",
+				Description: "Sets up a thread on each announcement for people to leave wishes in.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "enabled",
+						Description: "Whether to start a thread on each announcement.",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "name",
+						Description: "The thread's name. {username}, {age}, {date} and {server} are filled in for you. Use \"default\" to reset it.",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "archive",
+						Description: "What to do with the thread once the meatball day is over. Defaults to archiving it.",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Archive it", Value: models.WishesArchive},
+							{Name: "Archive and lock it", Value: models.WishesLock},
+							{Name: "Leave it open until it goes quiet", Value: models.WishesKeep},
+						},
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "reminders",
+				Description: "Sets how many days before a meatball day to send reminders.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "days",
+						Description: "Comma separated days before, e.g. 7,1. Use \"off\" to disable reminders.",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionChannel,
+						Name:        "channel",
+						Description: "The channel to post reminders in. Defaults to the announcement channel.",
+						Required:    false,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "digest",
+				Description: "Sets up a regular digest of upcoming meatball days.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "schedule",
+						Description: "How often to post the digest.",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Weekly", Value: models.DigestWeekly},
+							{Name: "Monthly, on the 1st", Value: models.DigestMonthly},
+							{Name: "Off", Value: "off"},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "weekday",
+						Description: "The day to post weekly digests on. Defaults to Monday.",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Monday", Value: int(time.Monday)},
+							{Name: "Tuesday", Value: int(time.Tuesday)},
+							{Name: "Wednesday", Value: int(time.Wednesday)},
+							{Name: "Thursday", Value: int(time.Thursday)},
+							{Name: "Friday", Value: int(time.Friday)},
+							{Name: "Saturday", Value: int(time.Saturday)},
+							{Name: "Sunday", Value: int(time.Sunday)},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "days",
+						Description: "How many days ahead the digest covers. Defaults to the time until the next one.",
+						Required:    false,
+					},
+				},
+			},
+		},
+	}, {
+		Name:         "meatball-feed",
+		Description:  "Manages the link to this server's live calendar feed.",
+		DMPermission: &guildOnly,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "generate",
+				Description: "Generates a new secret feed link. Any previous link stops working.",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "revoke",
+				Description: "Stops the current feed link from working.",
+			},
+		},
+	}, {
+		Name:         "meatball-import",
+		Description:  "Imports meatball days from a CSV or JSON file.",
+		DMPermission: &guildOnly,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionAttachment,
+				Name:        "file",
+				Description: "Rows of user (ID or name), date (MM-DD or YYYY-MM-DD) and optional year.",
+				Required:    true,
+			},
+		},
+	}, {
+		Name:         "meatball-export",
+		Description:  "Exports all of this server's casper data.",
+		DMPermission: &guildOnly,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "format",
+				Description: "The file format to export as.",
+				Required:    true,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "JSON", Value: export.FormatJSON},
+					{Name: "CSV", Value: export.FormatCSV},
+				},
+			},
+		},
+	}, {
+		Name:         "meatball-schedule",
+		Description:  "Shows when meatball roles will next be checked.",
+		DMPermission: &guildOnly,
+	}, {
+		Name:         "meatball-upcoming",
+		Description:  "Lists the upcoming meatball days.",
+		DMPermission: &guildOnly,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "count",
+				Description: "How many meatball days to list. Defaults to all of them.",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "days",
+				Description: "Only list meatball days within this many days.",
+				Required:    false,
+			},
+		},
+	}, {
+		Name:         "meatball-calendar",
+		Description:  "Shows a month of meatball days as a calendar.",
+		DMPermission: &guildOnly,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "month",
+				Description: "The month to show. Defaults to this month.",
+				Required:    false,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "January", Value: int(time.January)},
+					{Name: "February", Value: int(time.February)},
+					{Name: "March", Value: int(time.March)},
+					{Name: "April", Value: int(time.April)},
+					{Name: "May", Value: int(time.May)},
+					{Name: "June", Value: int(time.June)},
+					{Name: "July", Value: int(time.July)},
+					{Name: "August", Value: int(time.August)},
+					{Name: "September", Value: int(time.September)},
+					{Name: "October", Value: int(time.October)},
+					{Name: "November", Value: int(time.November)},
+					{Name: "December", Value: int(time.December)},
+				},
+			},
+		},
+	}, {
+		Name:         "meatball-ics",
+		Description:  "Exports the meatball days as a file for your calendar app.",
+		DMPermission: &guildOnly,
+	}, {
+		Name:         "meatball-next",
+		Description:  "Gets the next occurring meatball day.",
+		DMPermission: &guildOnly,
 	},
 }
 
@@ -75,11 +578,16 @@ type userID string
 
 // Bot represents an instance of the Casper discord bot.
 type Bot struct {
-	session            *discordgo.Session
-	db                 *gorm.DB
-	registeredCommands []*discordgo.ApplicationCommand
-	commandHandlers    map[string]commandHandler
-	lastSaveUsage      map[userID]time.Time
+	session           *discordgo.Session
+	db                *gorm.DB
+	commandHandlers   map[string]commandHandler
+	settingsHandlers  map[string]commandHandler
+	componentHandlers map[string]componentHandler
+	lastSaveUsage     map[userID]time.Time
+	scheduler         *Scheduler
+	feeds             *feedServer
+	imports           *importStore
+	undos             *undoStore
 }
 
 func (bot *Bot) initSession(token string, db *gorm.DB) {
@@ -94,12 +602,25 @@ func (bot *Bot) initSession(token string, db *gorm.DB) {
 		log.Println("Bot is up!")
 	})
 
+	session.AddHandler(func(*discordgo.Session, *discordgo.GuildCreate) {
+		// newly available guilds may need an earlier role check.
+		bot.scheduler.Reschedule()
+	})
+
 	session.AddHandler(func(
 		s *discordgo.Session,
 		i *discordgo.InteractionCreate,
 	) {
-		if handler, ok := bot.commandHandlers[i.Data.Name]; ok {
-			handler(i, db)
+		switch i.Type {
+		case discordgo.InteractionApplicationCommand:
+			if handler, ok := bot.commandHandlers[i.ApplicationCommandData().Name]; ok {
+				handler(i, db)
+			}
+		case discordgo.InteractionMessageComponent:
+			name, _ := splitCustomID(i.MessageComponentData().CustomID)
+			if handler, ok := bot.componentHandlers[name]; ok {
+				handler(i, db)
+			}
 		}
 	})
 
@@ -111,58 +632,115 @@ func (bot *Bot) initSession(token string, db *gorm.DB) {
 	bot.session = session
 }
 
+// Builds a component custom ID from a handler name and its arguments.
+func makeCustomID(name string, args ...string) string {
+	return strings.Join(append([]string{name}, args...), customIDSeparator)
+}
+
+// Splits a component custom ID into its handler name and arguments.
+func splitCustomID(customID string) (string, []string) {
+	parts := strings.Split(customID, customIDSeparator)
+	return parts[0], parts[1:]
+}
+
+// Registers casper's commands, replacing whatever was registered before so that
+// restarts don't count against discord's daily limit on creating commands.
 func (bot *Bot) registerCommands(guildID string) {
-	for _, command := range botCommands {
-		newCommand, err := bot.session.ApplicationCommandCreate(
-			bot.session.State.User.ID,
-			guildID,
-			command,
-		)
-		bot.registeredCommands = append(bot.registeredCommands, newCommand)
-		if err != nil {
-			log.Fatalf("Failed to create %v command: %v", command.Name, err)
-		}
-		log.Printf("Created %v command.", command.Name)
+	_, err := bot.session.ApplicationCommandBulkOverwrite(
+		bot.session.State.User.ID,
+		guildID,
+		botCommands,
+	)
+	if err != nil {
+		log.Fatalf("Failed to register commands: %v", err)
 	}
+	log.Printf("Registered %v commands.", len(botCommands))
 }
 
-// New initialises a new casper bot.
+// New initialises a new casper bot. If feedAddr is set, live calendar feeds are
+// served on it, with links pointing to feedURL.
 func New(
 	token string,
 	guildID string,
+	feedAddr string,
+	feedURL string,
 	db *gorm.DB,
 ) Bot {
-	bot := Bot{db: db, lastSaveUsage: make(map[userID]time.Time)}
+	bot := Bot{
+		db:            db,
+		lastSaveUsage: make(map[userID]time.Time),
+		scheduler:     NewScheduler(),
+		imports:       newImportStore(),
+		undos:         newUndoStore(),
+	}
+
+	// configured before the session opens, as interaction handlers read it.
+	bot.feeds = newFeedServer(feedAddr, feedURL, bot.handleFeed)
 
 	bot.commandHandlers = map[string]commandHandler{
-		"meatball":        bot.Meatball,
-		"meatball-save":   bot.MeatballSave,
-		"meatball-forget": bot.MeatballForget,
-		"meatball-role":   bot.MeatballRole,
-		"meatball-chan":   bot.MeatballChannel,
-		"meatball-next":   bot.MeatballNext,
+		"meatball":          bot.Meatball,
+		"meatball-save":     bot.MeatballSave,
+		"meatball-forget":   bot.MeatballForget,
+		"meatball-mydata":   bot.MeatballMyData,
+		"meatball-zone":     bot.MeatballZone,
+		"meatball-role":     bot.MeatballRole,
+		"meatball-chan":     bot.MeatballChannel,
+		"meatball-next":     bot.MeatballNext,
+		"meatball-upcoming": bot.MeatballUpcoming,
+		"meatball-calendar": bot.MeatballCalendar,
+		"meatball-ics":      bot.MeatballICS,
+		"meatball-private":  bot.MeatballPrivate,
+		"meatball-schedule": bot.MeatballSchedule,
+		"meatball-age":      bot.MeatballAge,
+		"meatball-follow":   bot.MeatballFollow,
+		"meatball-feed":     bot.MeatballFeed,
+		"meatball-import":   bot.MeatballImport,
+		"meatball-export":   bot.MeatballExport,
+		"meatball-unfollow": bot.MeatballUnfollow,
+		"meatball-settings": bot.MeatballSettings,
+	}
+
+	bot.settingsHandlers = map[string]commandHandler{
+		"zone":         bot.MeatballGuildZone,
+		"missed":       bot.MeatballMissed,
+		"leap":         bot.MeatballLeap,
+		"date-order":   bot.MeatballDateOrder,
+		"language":     bot.MeatballLanguage,
+		"milestone":    bot.MeatballMilestone,
+		"announcement": bot.MeatballAnnouncement,
+		"style":        bot.MeatballStyle,
+		"combine":      bot.MeatballCombine,
+		"wishes":       bot.MeatballWishes,
+		"reminders":    bot.MeatballReminders,
+		"digest":       bot.MeatballDigest,
+	}
+
+	bot.componentHandlers = map[string]componentHandler{
+		"meatball-upcoming": bot.MeatballUpcomingPage,
+		"meatball-calendar": bot.MeatballCalendarMonth,
+		"meatball-import":   bot.MeatballImportConfirm,
+		"meatball-mydata":   bot.MeatballMyDataErase,
+		"meatball-undo":     bot.MeatballUndo,
+		"meatball-save":     bot.MeatballSaveConfirm,
 	}
 
 	bot.initSession(token, db)
 	bot.registerCommands(guildID)
 
+	// feeds are looked up in the session's state, so they wait for it to open.
+	bot.feeds.start()
+
 	return bot
 }
 
-// Shutdown shuts down the bot cleanly.
-func (bot *Bot) Shutdown(guildID string) {
+// Shutdown shuts down the bot cleanly. Commands are left registered, to be
+// replaced when casper next starts.
+func (bot *Bot) Shutdown() {
 	log.Println("Shutting down.")
 
-	for _, command := range bot.registeredCommands {
-		err := bot.session.ApplicationCommandDelete(
-			bot.session.State.User.ID,
-			guildID,
-			command.ID,
-		)
-		if err != nil {
-			log.Printf("Failed to delete %v command: %v", command.Name, err)
-		} else {
-			log.Printf("Deleted %v command.", command.Name)
+	if bot.feeds.server != nil {
+		if err := bot.feeds.server.Close(); err != nil {
+			log.Printf("Failed to stop feed server: %v", err)
 		}
 	}
 
@@ -174,7 +752,7 @@ func (bot *Bot) CheckRoles() {
 	CheckRoles(bot.session, bot.db)
 }
 
-// RoleChecker invokes RoleChecker with this bot's session and database.
-func (bot *Bot) RoleChecker(ticker *time.Ticker, done chan bool) {
-	RoleChecker(bot.session, bot.db, ticker, done)
+// RunScheduler runs this bot's scheduler with its session and database.
+func (bot *Bot) RunScheduler(done chan bool) {
+	bot.scheduler.Run(bot.session, bot.db, done)
 }
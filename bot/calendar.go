@@ -0,0 +1,167 @@
+package bot
+
+import (
+	"casper/dal"
+	"casper/locale"
+	"casper/models"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+)
+
+// maxCalendarMentions is how many members are named on each day of the
+// calendar, the rest are counted.
+const maxCalendarMentions = 5
+
+// maxEmbedDescriptionLength is discord's limit on the length of an embed's
+// description.
+const maxEmbedDescriptionLength = 4096
+
+// Renders a month of the guild members' meatball days as a calendar grid.
+func calendarEmbed(
+	p locale.Printer,
+	guild *discordgo.Guild,
+	year int,
+	month time.Month,
+	db *gorm.DB,
+) (*discordgo.MessageEmbed, error) {
+	guildSettings, err := dal.GetGuildSettings(guild.ID, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var meatballDays []models.MeatballDay
+	err = db.Where(&models.MeatballDay{GuildID: guild.ID}).Find(&meatballDays).Error
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]*discordgo.Member)
+	for _, member := range guild.Members {
+		members[member.User.ID] = member
+	}
+
+	// day of the month -> mentions of everyone celebrating on it
+	meatballs := make(map[int][]string)
+	for _, meatballDay := range meatballDays {
+		member, ok := members[meatballDay.UserID]
+		if !ok {
+			continue
+		}
+
+		date, ok := meatballDay.Occurrence(year, *guildSettings)
+		if ok && date.Month() == month {
+			meatballs[date.Day()] = append(meatballs[date.Day()], member.Mention())
+		}
+	}
+
+	var grid strings.Builder
+	grid.WriteString("```\n")
+	for column := 0; column < 7; column++ {
+		// monday is the first column
+		weekday := []rune(p.Weekday(time.Weekday((column + 1) % 7)))
+		fmt.Fprintf(&grid, "%-4v", string(weekday[:2]))
+	}
+	grid.WriteString("\n")
+
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	daysInMonth := first.AddDate(0, 1, -1).Day()
+
+	// monday is the first column
+	column := (int(first.Weekday()) + 6) % 7
+	grid.WriteString(strings.Repeat("    ", column))
+
+	for day := 1; day <= daysInMonth; day++ {
+		marker := " "
+		if len(meatballs[day]) > 0 {
+			marker = "*"
+		}
+		fmt.Fprintf(&grid, "%2d%v ", day, marker)
+
+		column++
+		if column == 7 && day != daysInMonth {
+			grid.WriteString("\n")
+			column = 0
+		}
+	}
+	grid.WriteString("\n```")
+
+	days := make([]int, 0, len(meatballs))
+	for day := range meatballs {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+
+	moreDays := func(n int) string {
+		return p.Plural(n, "\n…and %v more day.", "\n…and %v more days.", n)
+	}
+
+	for i, day := range days {
+		mentions := meatballs[day]
+		if len(mentions) > maxCalendarMentions {
+			mentions = append(
+				mentions[:maxCalendarMentions:maxCalendarMentions],
+				p.Sprintf("%v more", len(mentions)-maxCalendarMentions),
+			)
+		}
+
+		line := fmt.Sprintf(
+			"\n**%v**: %v",
+			p.Date(first.AddDate(0, 0, day-1)),
+			p.List(mentions),
+		)
+
+		// always leave room to say how many days didn't fit.
+		more := ""
+		if remaining := len(days) - i - 1; remaining > 0 {
+			more = moreDays(remaining)
+		}
+
+		if utf8.RuneCountInString(grid.String()+line+more) > maxEmbedDescriptionLength {
+			grid.WriteString(moreDays(len(days) - i))
+			break
+		}
+
+		grid.WriteString(line)
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       p.Sprintf("Meatball days in %v %v", p.Month(month), year),
+		Description: grid.String(),
+	}, nil
+}
+
+// Builds the buttons for moving to the months either side of the given one.
+func calendarButtons(
+	p locale.Printer,
+	year int,
+	month time.Month,
+) []discordgo.MessageComponent {
+	monthButton := func(label string, offset int) discordgo.Button {
+		target := time.Date(year, month+time.Month(offset), 1, 0, 0, 0, 0, time.UTC)
+		return discordgo.Button{
+			Label: label,
+			Style: discordgo.SecondaryButton,
+			CustomID: makeCustomID(
+				"meatball-calendar",
+				strconv.Itoa(target.Year()),
+				strconv.Itoa(int(target.Month())),
+			),
+		}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				monthButton(p.Sprintf("Previous"), -1),
+				monthButton(p.Sprintf("Next"), 1),
+			},
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"casper/dal"
+	"casper/locale"
+	"casper/models"
+	"log"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+)
+
+// Meatball days that ended longer ago than this are never announced.
+const maxCatchUp = 7 * 24 * time.Hour
+
+// Announces every meatball day in the given guild that started after since and
+// ended before until, i.e. those that no role check ever saw.
+func announceMissedMeatballs(
+	p locale.Printer,
+	guild *discordgo.Guild,
+	since time.Time,
+	until time.Time,
+	guildSettings models.GuildSettings,
+	session *discordgo.Session,
+	db *gorm.DB,
+) {
+	if earliest := until.Add(-maxCatchUp); since.Before(earliest) {
+		since = earliest
+	}
+
+	meatballChannel, err := dal.GetMeatballChannel(guild.ID, db)
+	if err != nil {
+		log.Printf(
+			"Can't announce missed meatballs in %v: %v",
+			guild.Name,
+			err,
+		)
+		return
+	}
+
+	var meatballDays []models.MeatballDay
+	err = db.Where(&models.MeatballDay{GuildID: guild.ID}).Find(&meatballDays).Error
+	if err != nil {
+		log.Printf("Failed to find meatball days for guild %v: %v", guild.Name, err)
+		return
+	}
+
+	members := make(map[string]*discordgo.Member)
+	for _, member := range guild.Members {
+		members[member.User.ID] = member
+	}
+
+	var missedMembers []*discordgo.Member
+	meatballDaysForUserIDs := make(map[string]models.MeatballDay)
+	dates := make(map[string]time.Time)
+
+	for _, meatballDay := range meatballDays {
+		member, ok := members[meatballDay.UserID]
+		if !ok {
+			continue
+		}
+
+		if date, ok := missedMeatballDay(meatballDay, since, until, guildSettings); ok {
+			missedMembers = append(missedMembers, member)
+			meatballDaysForUserIDs[meatballDay.UserID] = meatballDay
+			dates[meatballDay.UserID] = date
+		}
+	}
+
+	announceMeatballs(
+		p,
+		guild,
+		missedMembers,
+		meatballDaysForUserIDs,
+		dates,
+		true,
+		guildSettings,
+		meatballChannel.ChannelID,
+		session,
+		db,
+	)
+}
+
+// Finds the start of an occurrence of the given meatball day that began after
+// since and was over by until.
+func missedMeatballDay(
+	meatballDay models.MeatballDay,
+	since time.Time,
+	until time.Time,
+	guildSettings models.GuildSettings,
+) (time.Time, bool) {
+	loc := meatballDay.Location(guildSettings)
+
+	for year := since.In(loc).Year(); year <= until.In(loc).Year(); year++ {
+		start, ok := meatballDay.Occurrence(year, guildSettings)
+		if !ok {
+			continue
+		}
+
+		end := start.AddDate(0, 0, 1)
+		if start.After(since) && !end.After(until) {
+			return start, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -1,15 +1,24 @@
 package bot
 
 import (
+	"bytes"
 	"casper/dal"
 	"casper/discordutils"
+	"casper/export"
+	"casper/ical"
+	"casper/locale"
 	"casper/models"
+	"errors"
 	"fmt"
 	"log"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/bwmarrin/discordgo"
-	"github.com/dustin/go-humanize"
 	"gorm.io/gorm"
 )
 
@@ -21,9 +30,15 @@ const (
 )
 
 const meatballSaveCooldown = 3 * 24 * time.Hour
+const minBirthYear = 1900
+const maxReminderDays = 365
+const maxDigestDays = 366
 const prettyDateFormat = "2006-01-02"
 const prettyTimeFormat = "15:04:05"
 
+// maxMessageLength is discord's limit on the length of a message.
+const maxMessageLength = 2000
+
 // Meatball looks up a meatball day in the meatball database.
 func (bot *Bot) Meatball(
 	i *discordgo.InteractionCreate,
@@ -31,9 +46,11 @@ func (bot *Bot) Meatball(
 ) {
 	discordutils.AckInteraction(i.Interaction, bot.session)
 
+	p := bot.printer(i, db)
+
 	var user *discordgo.User
-	if len(i.Data.Options) > 0 {
-		user = i.Data.Options[0].UserValue(nil)
+	if len(i.ApplicationCommandData().Options) > 0 {
+		user = i.ApplicationCommandData().Options[0].UserValue(nil)
 	} else {
 		user = i.Member.User
 	}
@@ -42,7 +59,7 @@ func (bot *Bot) Meatball(
 
 	meatballDay, err := dal.GetMeatballDay(i.GuildID, user.ID, db)
 	if err != nil {
-		reply = fmt.Sprintf(
+		reply = p.Sprintf(
 			"%v hasn't registered their meatball day with me yet.",
 			user.Mention(),
 		)
@@ -54,73 +71,468 @@ func (bot *Bot) Meatball(
 			0, 0, 0, 0,
 			time.UTC,
 		)
-		reply = fmt.Sprintf(
+		reply = p.Sprintf(
 			"I've got %v's meatball day down as %v.",
 			user.Mention(),
-			birthDate.Format(MeatballDayResponseExample),
+			p.Date(birthDate),
 		)
+		if meatballDay.TimeZone != "" {
+			reply += p.Sprintf(" (%v)", meatballDay.TimeZone)
+		}
+		if meatballDay.Year != 0 && meatballDay.ShowAge {
+			guildSettings, err := dal.GetGuildSettings(i.GuildID, db)
+			if err == nil {
+				reply += p.Sprintf(
+					" They're %v years old.",
+					meatballDay.Age(time.Now(), *guildSettings),
+				)
+			}
+		}
 	}
 
 	discordutils.SendFollowup(reply, i.Interaction, bot.session)
 }
 
-// MeatballSave saves a meatball day to the meatball day database.
+// MeatballSave saves a meatball day to the meatball day database. Dates that
+// aren't in one of the documented formats are confirmed before saving.
 func (bot *Bot) MeatballSave(
 	i *discordgo.InteractionCreate,
 	db *gorm.DB,
 ) {
 	discordutils.AckInteraction(i.Interaction, bot.session)
 
+	p := bot.printer(i, db)
+
 	var reply string
+	var components []discordgo.MessageComponent
 	saved := false // if true, triggers a role re-check at the end
 
 	if ok, lastUse := bot.userCanChangeMeatballDay(userID(i.Member.User.ID)); !ok {
-		nextUse := lastUse.Add(meatballSaveCooldown)
-		reply = fmt.Sprintf(
-			"You last changed your meatball day on %v at %v. "+
-				"You can change it again %v.",
-			lastUse.Format(prettyDateFormat),
-			lastUse.Format(prettyTimeFormat),
-			humanize.Time(nextUse),
-		)
+		reply = meatballCooldownReply(p, lastUse)
 	} else {
-		meatballDay := i.Data.Options[0].StringValue()
-		date, err := time.Parse(MeatballDayExample, meatballDay)
+		input := i.ApplicationCommandData().Options[0].StringValue()
 
-		if err != nil {
-			reply = fmt.Sprintf(
-				"Invalid date given! Make sure you use %v format. "+
-					"For example: %v (2nd January).",
+		dayFirst := false
+		if guildSettings, err := dal.GetGuildSettings(i.GuildID, db); err == nil {
+			dayFirst = guildSettings.DayFirst()
+		}
+
+		parsed, err := parseMeatballDay(input, dayFirst)
+
+		year := int64(parsed.year)
+		yearMismatch := false
+		if option, ok := discordutils.GetOption(i.ApplicationCommandData().Options, "year"); ok {
+			yearMismatch = parsed.year != 0 && option.IntValue() != year
+			year = option.IntValue()
+		}
+
+		if errors.Is(err, errUnknownDate) {
+			reply = p.Sprintf(
+				"I don't understand %q as a date. Try %v format, for example "+
+					"%v, or write it out like 2 Jan or January 2nd.",
+				input,
 				MeatballDayFormat,
 				MeatballDayExample,
 			)
+		} else if yearMismatch {
+			reply = p.Sprintf("The year %v doesn't match the year in %q.", year, input)
+		} else if year != 0 && !validBirthYear(int(year), parsed.date) {
+			reply = p.Sprintf(
+				"%v isn't a valid year for a meatball day on %v.",
+				year,
+				p.Date(parsed.date),
+			)
+		} else if !parsed.exact {
+			reply = p.Sprintf(
+				"I read %q as %v. Shall I save it?",
+				input,
+				prettyMeatballDay(p, parsed.date, int(year)),
+			)
+			components = saveConfirmButtons(p, i.Member.User.ID, parsed.date, int(year))
+		} else {
+			reply, components, saved = bot.saveMeatballDay(p, i, parsed.date, int(year), db)
+		}
+	}
+
+	discordutils.SendFollowupComplex(
+		&discordgo.WebhookParams{Content: reply, Components: components},
+		i.Interaction,
+		bot.session,
+	)
+
+	if saved {
+		bot.CheckRoles()
+	}
+}
+
+// MeatballSaveConfirm saves or discards a meatball day that was read from a
+// loosely formatted date.
+func (bot *Bot) MeatballSaveConfirm(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	// only the user who gave the date gets to confirm it.
+	_, args := splitCustomID(i.MessageComponentData().CustomID)
+	if len(args) == 0 || args[0] != i.Member.User.ID {
+		return
+	}
+
+	discordutils.AckComponentInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	var reply string
+	var components []discordgo.MessageComponent
+	saved := false // if true, triggers a role re-check at the end
+
+	if len(args) != 4 {
+		reply = p.Sprintf("Okay, I haven't saved anything.")
+	} else if ok, lastUse := bot.userCanChangeMeatballDay(userID(i.Member.User.ID)); !ok {
+		reply = meatballCooldownReply(p, lastUse)
+	} else {
+		month, _ := strconv.Atoi(args[1])
+		day, _ := strconv.Atoi(args[2])
+		year, _ := strconv.Atoi(args[3])
+		date := time.Date(2000, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+
+		reply, components, saved = bot.saveMeatballDay(p, i, date, year, db)
+	}
+
+	discordutils.EditInteractionResponse(
+		&discordgo.WebhookEdit{
+			Content:    &reply,
+			Components: &components,
+		},
+		i.Interaction,
+		bot.session,
+	)
+
+	if saved {
+		bot.CheckRoles()
+	}
+}
+
+// Saves the interacting member's meatball day, returning the reply to send and
+// whether anything was saved.
+func (bot *Bot) saveMeatballDay(
+	p locale.Printer,
+	i *discordgo.InteractionCreate,
+	date time.Time,
+	year int,
+	db *gorm.DB,
+) (string, []discordgo.MessageComponent, bool) {
+	previous, err := dal.ReplaceMeatballDay(
+		models.MeatballDay{
+			GuildID: i.GuildID,
+			UserID:  i.Member.User.ID,
+			Month:   uint(date.Month()),
+			Day:     uint(date.Day()),
+			Year:    uint(year),
+		},
+		db,
+	)
+
+	if err != nil {
+		reply := p.Sprintf(
+			"Failed to set %v's meatball day: %v",
+			i.Member.Mention(),
+			err,
+		)
+		return reply, nil, false
+	}
+
+	// saving without a year keeps the one already saved, unless it doesn't fit
+	// the new date.
+	if year == 0 && previous != nil && previous.Year != 0 {
+		if validBirthYear(int(previous.Year), date) {
+			year = int(previous.Year)
 		} else {
-			err := dal.UpsertMeatballDay(
-				models.MeatballDay{
-					GuildID: i.GuildID,
-					UserID:  i.Member.User.ID,
-					Month:   uint(date.Month()),
-					Day:     uint(date.Day()),
+			err := dal.SetMeatballDayYear(i.GuildID, i.Member.User.ID, 0, db)
+			if err != nil {
+				log.Printf("Failed to clear %v's year: %v", i.Member.User.Username, err)
+			}
+		}
+	}
+
+	bot.undos.drop(i.GuildID, i.Member.User.ID)
+	bot.lastSaveUsage[userID(i.Member.User.ID)] = time.Now()
+	reply := p.Sprintf(
+		"Saved %v as %v's meatball day.",
+		prettyMeatballDay(p, date, year),
+		i.Member.Mention(),
+	)
+	return reply, bot.undoButton(p, previous), true
+}
+
+// Returns buttons to confirm or discard saving the given meatball day.
+func saveConfirmButtons(
+	p locale.Printer,
+	userID string,
+	date time.Time,
+	year int,
+) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label: p.Sprintf("Save"),
+					Style: discordgo.SuccessButton,
+					CustomID: makeCustomID(
+						"meatball-save",
+						userID,
+						strconv.Itoa(int(date.Month())),
+						strconv.Itoa(date.Day()),
+						strconv.Itoa(year),
+					),
 				},
-				db,
+				discordgo.Button{
+					Label:    p.Sprintf("Cancel"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: makeCustomID("meatball-save", userID),
+				},
+			},
+		},
+	}
+}
+
+// MeatballForget removes a user's meatball day from the database.
+func (bot *Bot) MeatballForget(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	var reply string
+	var components []discordgo.MessageComponent
+
+	if ok, lastUse := bot.userCanChangeMeatballDay(userID(i.Member.User.ID)); !ok {
+		reply = meatballCooldownReply(p, lastUse)
+	} else {
+		previous, err := dal.DeleteMeatballDay(i.GuildID, i.Member.User.ID, db)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			reply = p.Sprintf(
+				"I don't seem to have your meatball day on record. " +
+					"Isn't that a lovely coincidence?",
+			)
+		} else if err != nil {
+			reply = p.Sprintf(
+				"I'm unable to delete your meatball day from my database: %v\n"+
+					"Please contact an admin to resolve this issue.",
+				err,
 			)
+		} else {
+			reply = p.Sprintf("I have erased your meatball day from my database.")
+			components = bot.undoButton(p, previous)
+		}
+	}
 
-			if err != nil {
-				reply = fmt.Sprintf(
-					"Failed to set %v's meatball day: %v",
-					i.Member.Mention(),
-					err,
-				)
-			} else {
-				bot.lastSaveUsage[userID(i.Member.User.ID)] = time.Now()
-				reply = fmt.Sprintf(
-					"Saved %v as %v's meatball day.",
-					date.Format(MeatballDayResponseExample),
-					i.Member.Mention(),
-				)
-				saved = true
+	discordutils.SendFollowupComplex(
+		&discordgo.WebhookParams{Content: reply, Components: components},
+		i.Interaction,
+		bot.session,
+	)
+}
+
+// MeatballUndo restores a user's meatball day to how it was before they last
+// saved or forgot it.
+func (bot *Bot) MeatballUndo(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	// only the user who made the change gets to undo it.
+	_, args := splitCustomID(i.MessageComponentData().CustomID)
+	if len(args) != 2 || args[1] != i.Member.User.ID {
+		return
+	}
+
+	discordutils.AckComponentInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	content := i.Message.Content
+	restored := false // if true, triggers a role re-check at the end
+
+	pending, ok := bot.undos.take(args[0])
+	if !ok {
+		content += p.Sprintf("\nIt's too late to undo this.")
+	} else if err := dal.RestoreMeatballDay(pending.previous, db); err != nil {
+		content += p.Sprintf("\nFailed to undo: %v", err)
+	} else {
+		date := time.Date(
+			2000,
+			time.Month(pending.previous.Month),
+			int(pending.previous.Day),
+			0, 0, 0, 0,
+			time.UTC,
+		)
+		content = p.Sprintf(
+			"Put %v's meatball day back to %v.",
+			i.Member.Mention(),
+			prettyMeatballDay(p, date, int(pending.previous.Year)),
+		)
+		restored = true
+	}
+
+	discordutils.EditInteractionResponse(
+		&discordgo.WebhookEdit{
+			Content:    &content,
+			Components: &[]discordgo.MessageComponent{},
+		},
+		i.Interaction,
+		bot.session,
+	)
+
+	if restored {
+		bot.CheckRoles()
+	}
+}
+
+// MeatballMyData sends a user everything casper stores about them, or offers
+// to erase it.
+func (bot *Bot) MeatballMyData(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteractionEphemeral(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	// this is the one command that can be used in DMs, where there's no member.
+	userID := discordutils.InteractionUser(i.Interaction).ID
+	params := &discordgo.WebhookParams{}
+
+	switch i.ApplicationCommandData().Options[0].Name {
+	case "export":
+		var buffer bytes.Buffer
+		user, err := export.LoadUser(userID, db)
+		if err == nil {
+			err = user.WriteJSON(&buffer)
+		}
+
+		if err != nil {
+			params.Content = p.Sprintf("Failed to export your data: %v", err)
+		} else {
+			params.Content = p.Sprintf("Here's everything I know about you, in every server.")
+			params.Files = []*discordgo.File{
+				{
+					Name:        "casper-mydata.json",
+					ContentType: "application/json",
+					Reader:      &buffer,
+				},
 			}
 		}
+	case "erase":
+		params.Content = p.Sprintf(
+			"This will permanently erase your meatball days, follows and " +
+				"reminder history in every server. It can't be undone. Are you sure?",
+		)
+		params.Components = []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    p.Sprintf("Erase everything"),
+						Style:    discordgo.DangerButton,
+						CustomID: makeCustomID("meatball-mydata", userID, "erase"),
+					},
+					discordgo.Button{
+						Label:    p.Sprintf("Cancel"),
+						Style:    discordgo.SecondaryButton,
+						CustomID: makeCustomID("meatball-mydata", userID, "cancel"),
+					},
+				},
+			},
+		}
+	}
+
+	discordutils.SendFollowupComplex(params, i.Interaction, bot.session)
+}
+
+// MeatballMyDataErase carries out or cancels an erase of a user's data.
+func (bot *Bot) MeatballMyDataErase(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	_, args := splitCustomID(i.MessageComponentData().CustomID)
+	if len(args) != 2 || args[0] != discordutils.InteractionUser(i.Interaction).ID {
+		return
+	}
+
+	discordutils.AckComponentInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	var content string
+	erased := false // if true, triggers a role re-check at the end
+
+	if args[1] != "erase" {
+		content = p.Sprintf("Your data has been left alone.")
+	} else if err := dal.EraseUserData(args[0], db); err != nil {
+		content = p.Sprintf(
+			"I'm unable to erase your data: %v\n"+
+				"Please contact an admin to resolve this issue.",
+			err,
+		)
+	} else {
+		bot.undos.dropUser(args[0])
+		content = p.Sprintf("I have erased everything I knew about you.")
+		erased = true
+	}
+
+	discordutils.EditInteractionResponse(
+		&discordgo.WebhookEdit{
+			Content:    &content,
+			Components: &[]discordgo.MessageComponent{},
+		},
+		i.Interaction,
+		bot.session,
+	)
+
+	if erased {
+		bot.CheckRoles()
+	}
+}
+
+// MeatballZone sets the time zone a user's meatball day is celebrated in.
+func (bot *Bot) MeatballZone(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	var reply string
+	saved := false // if true, triggers a role re-check at the end
+
+	zone := i.ApplicationCommandData().Options[0].StringValue()
+	loc, err := models.LoadTimeZone(zone)
+	if err != nil {
+		reply = p.Sprintf(
+			"I don't know the time zone %v. "+
+				"Use a name from the IANA time zone database, such as Europe/London.",
+			zone,
+		)
+	} else {
+		err = dal.SetMeatballDayTimeZone(i.GuildID, i.Member.User.ID, loc.String(), db)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			reply = p.Sprintf("You need to save your meatball day before you can set its time zone.")
+		} else if err != nil {
+			reply = p.Sprintf(
+				"Failed to set %v's time zone: %v",
+				i.Member.Mention(),
+				err,
+			)
+		} else {
+			reply = p.Sprintf(
+				"I will now celebrate %v's meatball day in %v.",
+				i.Member.Mention(),
+				loc,
+			)
+			saved = true
+			bot.scheduler.Reschedule()
+		}
 	}
 
 	discordutils.SendFollowup(reply, i.Interaction, bot.session)
@@ -130,46 +542,144 @@ func (bot *Bot) MeatballSave(
 	}
 }
 
-// MeatballForget removes a user's meatball day from the database.
-func (bot *Bot) MeatballForget(
+// MeatballAge sets whether a user's age may be shown and announced.
+func (bot *Bot) MeatballAge(
 	i *discordgo.InteractionCreate,
 	db *gorm.DB,
 ) {
 	discordutils.AckInteraction(i.Interaction, bot.session)
 
+	p := bot.printer(i, db)
+
 	var reply string
 
-	if ok, lastUse := bot.userCanChangeMeatballDay(userID(i.Member.User.ID)); !ok {
-		nextUse := lastUse.Add(meatballSaveCooldown)
-		reply = fmt.Sprintf(
-			"You last changed your meatball day on %v at %v. "+
-				"You can change it again %v.",
-			lastUse.Format(prettyDateFormat),
-			lastUse.Format(prettyTimeFormat),
-			humanize.Time(nextUse),
+	show := i.ApplicationCommandData().Options[0].BoolValue()
+	err := dal.SetMeatballDayShowAge(i.GuildID, i.Member.User.ID, show, db)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		reply = p.Sprintf("You need to save your meatball day before you can choose whether to show your age.")
+	} else if err != nil {
+		reply = p.Sprintf(
+			"Failed to update %v's age settings: %v",
+			i.Member.Mention(),
+			err,
+		)
+	} else if show {
+		reply = p.Sprintf("I will show your age, as long as you've told me the year you were born.")
+	} else {
+		reply = p.Sprintf("I will keep your age to myself.")
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+}
+
+// MeatballPrivate sets whether a user's meatball day is left out of calendar
+// exports.
+func (bot *Bot) MeatballPrivate(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	var reply string
+
+	private := i.ApplicationCommandData().Options[0].BoolValue()
+	err := dal.SetMeatballDayPrivate(i.GuildID, i.Member.User.ID, private, db)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		reply = p.Sprintf("You need to save your meatball day before you can hide it.")
+	} else if err != nil {
+		reply = p.Sprintf(
+			"Failed to update %v's privacy settings: %v",
+			i.Member.Mention(),
+			err,
 		)
+	} else if private {
+		reply = p.Sprintf("I will leave your meatball day out of calendar exports.")
+	} else {
+		reply = p.Sprintf("I will include your meatball day in calendar exports.")
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+}
+
+// MeatballFollow subscribes a user to DM reminders of another user's meatball
+// day.
+func (bot *Bot) MeatballFollow(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	user := i.ApplicationCommandData().Options[0].UserValue(nil)
+
+	var reply string
+
+	if user.ID == i.Member.User.ID {
+		reply = p.Sprintf("I'm sure you'll remember your own meatball day.")
 	} else {
-		meatballDay, err := dal.GetMeatballDay(i.GuildID, i.Member.User.ID, db)
+		err := dal.InsertMeatballFollow(
+			models.MeatballFollow{
+				GuildID:        i.GuildID,
+				UserID:         i.Member.User.ID,
+				FollowedUserID: user.ID,
+			},
+			db,
+		)
+
 		if err != nil {
-			reply = "I don't seem to have your meatball day on record. " +
-				"Isn't that a lovely coincidence?"
+			reply = p.Sprintf("Failed to follow %v: %v", user.Mention(), err)
 		} else {
-			err = db.Delete(&meatballDay).Error
-			if err != nil {
-				reply = fmt.Sprintf(
-					"I'm unable to delete your meatball day from my database: %v\n"+
-						"Please contact an admin to resolve this issue.",
-					err,
-				)
-			} else {
-				reply = "I have erased your meatball day from my database."
-			}
+			reply = p.Sprintf(
+				"I will DM you reminders before %v's meatball day, "+
+					"as long as this server has reminders enabled.",
+				user.Mention(),
+			)
 		}
 	}
 
 	discordutils.SendFollowup(reply, i.Interaction, bot.session)
 }
 
+// MeatballUnfollow unsubscribes a user from DM reminders of another user's
+// meatball day.
+func (bot *Bot) MeatballUnfollow(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	user := i.ApplicationCommandData().Options[0].UserValue(nil)
+
+	var reply string
+
+	err := dal.DeleteMeatballFollow(
+		models.MeatballFollow{
+			GuildID:        i.GuildID,
+			UserID:         i.Member.User.ID,
+			FollowedUserID: user.ID,
+		},
+		db,
+	)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		reply = p.Sprintf("You weren't following %v.", user.Mention())
+	} else if err != nil {
+		reply = p.Sprintf("Failed to unfollow %v: %v", user.Mention(), err)
+	} else {
+		reply = p.Sprintf(
+			"I will no longer DM you reminders before %v's meatball day.",
+			user.Mention(),
+		)
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+}
+
 // MeatballRole sets the role to use on a user's meatball day
 func (bot *Bot) MeatballRole(
 	i *discordgo.InteractionCreate,
@@ -177,6 +687,8 @@ func (bot *Bot) MeatballRole(
 ) {
 	discordutils.AckInteraction(i.Interaction, bot.session)
 
+	p := bot.printer(i, db)
+
 	guild, err := bot.session.State.Guild(i.GuildID)
 	if err != nil {
 		log.Panicf(
@@ -188,10 +700,10 @@ func (bot *Bot) MeatballRole(
 	var reply string
 
 	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
-		role := i.Data.Options[0].RoleValue(bot.session, i.GuildID)
+		role := i.ApplicationCommandData().Options[0].RoleValue(bot.session, i.GuildID)
 
 		if discordutils.RoleAllowsAdminPermissions(role) {
-			reply = "That role allows admin permissions, that's a bad idea."
+			reply = p.Sprintf("That role allows admin permissions, that's a bad idea.")
 		} else {
 			err := dal.UpsertMeatballRole(
 				models.MeatballRole{
@@ -202,16 +714,16 @@ func (bot *Bot) MeatballRole(
 			)
 
 			if err != nil {
-				reply = fmt.Sprintf("Failed to set new role: %v", err)
+				reply = p.Sprintf("Failed to set new role: %v", err)
 			} else {
-				reply = fmt.Sprintf(
+				reply = p.Sprintf(
 					"I will now assign %v on meatball day.",
 					role.Mention(),
 				)
 			}
 		}
 	} else {
-		reply = "Nice try."
+		reply = p.Sprintf("Nice try.")
 	}
 
 	discordutils.SendFollowup(reply, i.Interaction, bot.session)
@@ -224,6 +736,8 @@ func (bot *Bot) MeatballChannel(
 ) {
 	discordutils.AckInteraction(i.Interaction, bot.session)
 
+	p := bot.printer(i, db)
+
 	guild, err := bot.session.State.Guild(i.GuildID)
 	if err != nil {
 		log.Panicf(
@@ -235,7 +749,7 @@ func (bot *Bot) MeatballChannel(
 	var reply string
 
 	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
-		channel := i.Data.Options[0].ChannelValue(nil)
+		channel := i.ApplicationCommandData().Options[0].ChannelValue(nil)
 
 		err := dal.UpsertMeatballChannel(
 			models.MeatballChannel{
@@ -246,51 +760,1547 @@ func (bot *Bot) MeatballChannel(
 		)
 
 		if err != nil {
-			reply = fmt.Sprintf("Failed to set new channel: %v", err)
+			reply = p.Sprintf("Failed to set new channel: %v", err)
 		} else {
-			reply = fmt.Sprintf(
+			reply = p.Sprintf(
 				"I will now use %v for announcements.",
 				channel.Mention(),
 			)
 		}
 	} else {
-		reply = "Nice try."
+		reply = p.Sprintf("Nice try.")
 	}
 
 	discordutils.SendFollowup(reply, i.Interaction, bot.session)
 }
 
-// MeatballNext finds the next occurring meatball day.
-func (bot *Bot) MeatballNext(
+// MeatballSettings routes a /meatball-settings subcommand to its handler. The
+// handler sees the subcommand's options as though it were a command of its own.
+func (bot *Bot) MeatballSettings(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	data := i.ApplicationCommandData()
+	subcommand := data.Options[0]
+
+	handler, ok := bot.settingsHandlers[subcommand.Name]
+	if !ok {
+		return
+	}
+
+	data.Name = subcommand.Name
+	data.Options = subcommand.Options
+
+	interaction := *i.Interaction
+	interaction.Data = data
+	handler(&discordgo.InteractionCreate{Interaction: &interaction}, db)
+}
+
+// MeatballGuildZone sets the default time zone for a guild.
+func (bot *Bot) MeatballGuildZone(
 	i *discordgo.InteractionCreate,
 	db *gorm.DB,
 ) {
 	discordutils.AckInteraction(i.Interaction, bot.session)
-	nextMeatballDay, err := dal.GetNextMeatballDay(i.GuildID, db)
 
-	var reply string
+	p := bot.printer(i, db)
 
+	guild, err := bot.session.State.Guild(i.GuildID)
 	if err != nil {
-		reply = fmt.Sprintf("Failed to get next meatball day: %v", err)
-	} else if nextMeatballDay == nil {
-		reply = "There are no meatball days registered yet."
-	} else {
-		date := time.Date(
-			0,
-			time.Month(nextMeatballDay.Month),
-			int(nextMeatballDay.Day),
-			0, 0, 0, 0,
-			time.UTC,
-		)
-
-		reply = fmt.Sprintf(
-			"The next meatball day is <@%v>'s on %v.",
-			nextMeatballDay.UserID,
-			date.Format(MeatballDayResponseExample),
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
 		)
 	}
 
-	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+	var reply string
+	saved := false // if true, triggers a role re-check at the end
+
+	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
+		zone := i.ApplicationCommandData().Options[0].StringValue()
+		loc, err := models.LoadTimeZone(zone)
+		if err != nil {
+			reply = p.Sprintf(
+				"I don't know the time zone %v. "+
+					"Use a name from the IANA time zone database, such as Europe/London.",
+				zone,
+			)
+		} else {
+			err := dal.UpsertGuildSettings(
+				models.GuildSettings{
+					GuildID:  guild.ID,
+					TimeZone: loc.String(),
+				},
+				[]string{"time_zone"},
+				db,
+			)
+
+			if err != nil {
+				reply = p.Sprintf("Failed to set time zone: %v", err)
+			} else {
+				reply = p.Sprintf(
+					"I will now use %v for anyone who hasn't set their own time zone.",
+					loc,
+				)
+				saved = true
+				bot.scheduler.Reschedule()
+			}
+		}
+	} else {
+		reply = p.Sprintf("Nice try.")
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+
+	if saved {
+		bot.CheckRoles()
+	}
+}
+
+// MeatballMissed sets the policy for meatball days missed while offline.
+func (bot *Bot) MeatballMissed(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	var reply string
+
+	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
+		policy := i.ApplicationCommandData().Options[0].StringValue()
+
+		err := dal.UpsertGuildSettings(
+			models.GuildSettings{
+				GuildID:         guild.ID,
+				MissedDayPolicy: policy,
+			},
+			[]string{"missed_day_policy"},
+			db,
+		)
+
+		if err != nil {
+			reply = p.Sprintf("Failed to set missed day policy: %v", err)
+		} else if policy == models.MissedDaySkip {
+			reply = p.Sprintf("I will no longer announce meatball days I missed while offline.")
+		} else {
+			reply = p.Sprintf("I will announce meatball days I missed while offline as soon as I'm back.")
+		}
+	} else {
+		reply = p.Sprintf("Nice try.")
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+}
+
+// MeatballLeap sets the policy for 29th February meatball days in non-leap
+// years.
+func (bot *Bot) MeatballLeap(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	var reply string
+	saved := false // if true, triggers a role re-check at the end
+
+	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
+		policy := i.ApplicationCommandData().Options[0].StringValue()
+
+		err := dal.UpsertGuildSettings(
+			models.GuildSettings{
+				GuildID:       guild.ID,
+				LeapDayPolicy: policy,
+			},
+			[]string{"leap_day_policy"},
+			db,
+		)
+
+		if err != nil {
+			reply = p.Sprintf("Failed to set leap day policy: %v", err)
+		} else {
+			switch policy {
+			case models.LeapDayMarch1:
+				reply = p.Sprintf("In non-leap years, I will celebrate 29th February meatball days on 1st March.")
+			case models.LeapDaySkip:
+				reply = p.Sprintf("In non-leap years, I will skip 29th February meatball days.")
+			default:
+				reply = p.Sprintf("In non-leap years, I will celebrate 29th February meatball days on 28th February.")
+			}
+			saved = true
+		}
+	} else {
+		reply = p.Sprintf("Nice try.")
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+
+	if saved {
+		bot.CheckRoles()
+	}
+}
+
+// MeatballDateOrder sets how numeric dates are read by /meatball-save.
+func (bot *Bot) MeatballDateOrder(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	var reply string
+
+	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
+		order := i.ApplicationCommandData().Options[0].StringValue()
+
+		err := dal.UpsertGuildSettings(
+			models.GuildSettings{
+				GuildID:   guild.ID,
+				DateOrder: order,
+			},
+			[]string{"date_order"},
+			db,
+		)
+
+		if err != nil {
+			reply = p.Sprintf("Failed to set date order: %v", err)
+		} else if order == models.DateOrderDayMonth {
+			reply = p.Sprintf("I will read dates like 2/1 as 2nd January.")
+		} else {
+			reply = p.Sprintf("I will read dates like 2/1 as 1st February.")
+		}
+	} else {
+		reply = p.Sprintf("Nice try.")
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+}
+
+// MeatballLanguage sets the language casper speaks in a guild.
+func (bot *Bot) MeatballLanguage(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	var reply string
+
+	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
+		language := i.ApplicationCommandData().Options[0].StringValue()
+		if language == languageAuto {
+			language = ""
+		}
+
+		err := dal.UpsertGuildSettings(
+			models.GuildSettings{
+				GuildID:  guild.ID,
+				Language: language,
+			},
+			[]string{"language"},
+			db,
+		)
+
+		// reply in the new language, so the admin can see what they chose.
+		p := guildPrinter(guild, models.GuildSettings{Language: language})
+
+		if err != nil {
+			reply = p.Sprintf("Failed to set language: %v", err)
+		} else if language == "" {
+			reply = p.Sprintf("I will speak this server's Discord language.")
+		} else {
+			reply = p.Sprintf("I will speak %v in this server.", locale.Name(language))
+		}
+	} else {
+		reply = bot.printer(i, db).Sprintf("Nice try.")
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+}
+
+// MeatballMilestone manages the special announcements for milestone ages.
+func (bot *Bot) MeatballMilestone(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	var reply string
+	var files []*discordgo.File
+
+	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
+		subcommand := i.ApplicationCommandData().Options[0]
+
+		switch subcommand.Name {
+		case "set":
+			age := subcommand.Options[0].IntValue()
+			template := subcommand.Options[1].StringValue()
+
+			if age < 1 {
+				reply = p.Sprintf("Milestone ages have to be at least 1.")
+			} else if err := validateTemplate(template); err != nil {
+				reply = templateErrorReply(p, err)
+			} else {
+				err := dal.UpsertMilestoneTemplate(
+					models.MilestoneTemplate{
+						GuildID:  guild.ID,
+						Age:      uint(age),
+						Template: template,
+					},
+					db,
+				)
+
+				if err != nil {
+					reply = p.Sprintf("Failed to set milestone announcement: %v", err)
+				} else {
+					reply = p.Sprintf(
+						"I will announce everyone's %v meatball day with: %v",
+						p.Ordinal(int(age)),
+						template,
+					)
+				}
+			}
+		case "remove":
+			age := subcommand.Options[0].IntValue()
+
+			if age < 1 {
+				reply = p.Sprintf("Milestone ages have to be at least 1.")
+				break
+			}
+
+			err := dal.DeleteMilestoneTemplate(guild.ID, uint(age), db)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				reply = p.Sprintf("There's no milestone announcement for age %v.", age)
+			} else if err != nil {
+				reply = p.Sprintf("Failed to remove milestone announcement: %v", err)
+			} else {
+				reply = p.Sprintf("Removed the milestone announcement for age %v.", age)
+			}
+		case "list":
+			milestoneTemplates, err := dal.GetMilestoneTemplates(guild.ID, db)
+			if err != nil {
+				reply = p.Sprintf("Failed to get milestone announcements: %v", err)
+			} else if len(milestoneTemplates) == 0 {
+				reply = p.Sprintf("There are no milestone announcements set up yet.")
+			} else {
+				lines := make([]string, len(milestoneTemplates))
+				for i, milestoneTemplate := range milestoneTemplates {
+					lines[i] = p.Sprintf(
+						"\n**%v**: %v",
+						milestoneTemplate.Age,
+						milestoneTemplate.Template,
+					)
+				}
+
+				reply, files = longList(
+					p,
+					p.Sprintf("Milestone announcements:"),
+					lines,
+					"milestones.txt",
+				)
+			}
+		}
+	} else {
+		reply = p.Sprintf("Nice try.")
+	}
+
+	discordutils.SendFollowupComplex(
+		&discordgo.WebhookParams{
+			Content: reply,
+			Files:   files,
+		},
+		i.Interaction,
+		bot.session,
+	)
+}
+
+// MeatballAnnouncement manages the announcements a guild picks from at random
+// on meatball days.
+func (bot *Bot) MeatballAnnouncement(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	var reply string
+	var files []*discordgo.File
+
+	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
+		subcommand := i.ApplicationCommandData().Options[0]
+
+		switch subcommand.Name {
+		case "add":
+			template := subcommand.Options[0].StringValue()
+
+			combined := false
+			if option, ok := discordutils.GetOption(subcommand.Options, "combined"); ok {
+				combined = option.BoolValue()
+			}
+
+			if err := validateTemplate(template); err != nil {
+				reply = templateErrorReply(p, err)
+			} else if combined && usesAge(template) {
+				reply = p.Sprintf("Combined announcements can't use {age}, as everyone's age is different.")
+			} else {
+				announcementTemplate := models.AnnouncementTemplate{
+					GuildID:  guild.ID,
+					Template: template,
+					Combined: combined,
+				}
+
+				err := dal.CreateAnnouncementTemplate(&announcementTemplate, db)
+				if err != nil {
+					reply = p.Sprintf("Failed to add announcement: %v", err)
+				} else if combined {
+					reply = p.Sprintf(
+						"Added combined announcement %v: %v",
+						announcementTemplate.ID,
+						template,
+					)
+				} else {
+					reply = p.Sprintf(
+						"Added announcement %v: %v",
+						announcementTemplate.ID,
+						template,
+					)
+				}
+			}
+		case "remove":
+			id := subcommand.Options[0].IntValue()
+
+			err := dal.DeleteAnnouncementTemplate(guild.ID, uint(id), db)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				reply = p.Sprintf("There's no announcement %v.", id)
+			} else if err != nil {
+				reply = p.Sprintf("Failed to remove announcement: %v", err)
+			} else {
+				reply = p.Sprintf("Removed announcement %v.", id)
+			}
+		case "list":
+			announcementTemplates, err := dal.GetAnnouncementTemplates(guild.ID, db)
+			if err != nil {
+				reply = p.Sprintf("Failed to get announcements: %v", err)
+			} else if len(announcementTemplates) == 0 {
+				reply = p.Sprintf("There are no announcements set up yet, so I'm using the default one.")
+			} else {
+				lines := make([]string, len(announcementTemplates))
+				for i, announcementTemplate := range announcementTemplates {
+					format := "\n**%v**: %v"
+					if announcementTemplate.Combined {
+						format = "\n**%v** (combined): %v"
+					}
+
+					lines[i] = p.Sprintf(
+						format,
+						announcementTemplate.ID,
+						announcementTemplate.Template,
+					)
+				}
+
+				reply, files = longList(
+					p,
+					p.Sprintf("Announcements, one of which is picked at random:"),
+					lines,
+					"announcements.txt",
+				)
+			}
+		}
+	} else {
+		reply = p.Sprintf("Nice try.")
+	}
+
+	discordutils.SendFollowupComplex(
+		&discordgo.WebhookParams{
+			Content: reply,
+			Files:   files,
+		},
+		i.Interaction,
+		bot.session,
+	)
+}
+
+// MeatballStyle sets whether a guild's meatball days are announced in a plain
+// message or an embed.
+func (bot *Bot) MeatballStyle(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	var reply string
+
+	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
+		options := i.ApplicationCommandData().Options
+		style := options[0].StringValue()
+
+		guildSettings := models.GuildSettings{
+			GuildID:           guild.ID,
+			AnnouncementStyle: style,
+		}
+		columns := []string{"announcement_style"}
+
+		// the color and image are only changed if they're given, so that the
+		// style can be switched without losing them.
+		if option, ok := discordutils.GetOption(options, "color"); ok {
+			color := strings.TrimSpace(option.StringValue())
+			if !strings.EqualFold(color, "default") {
+				parsedColor, ok := parseColor(color)
+				if !ok {
+					reply = p.Sprintf("%q isn't a color. Use a hex code like #e67e22.", color)
+				}
+				guildSettings.AnnouncementColor = &parsedColor
+			}
+			columns = append(columns, "announcement_color")
+		}
+
+		if option, ok := discordutils.GetOption(options, "image"); ok {
+			imageURL := strings.TrimSpace(option.StringValue())
+			if strings.EqualFold(imageURL, "none") {
+				imageURL = ""
+			} else if !isWebURL(imageURL) {
+				reply = p.Sprintf("%q isn't a link to an image. Use an http or https link.", imageURL)
+			}
+			guildSettings.AnnouncementImageURL = imageURL
+			columns = append(columns, "announcement_image_url")
+		}
+
+		if reply == "" {
+			err := dal.UpsertGuildSettings(guildSettings, columns, db)
+
+			if err != nil {
+				reply = p.Sprintf("Failed to set announcement style: %v", err)
+			} else if style == models.AnnouncementEmbed {
+				reply = p.Sprintf("I will announce meatball days in an embed.")
+			} else {
+				reply = p.Sprintf("I will announce meatball days in a plain message.")
+			}
+		}
+	} else {
+		reply = p.Sprintf("Nice try.")
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+}
+
+// MeatballCombine sets whether people who share a meatball day are announced
+// in a single message.
+func (bot *Bot) MeatballCombine(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	var reply string
+
+	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
+		combine := i.ApplicationCommandData().Options[0].BoolValue()
+
+		err := dal.UpsertGuildSettings(
+			models.GuildSettings{
+				GuildID:              guild.ID,
+				CombineAnnouncements: combine,
+			},
+			[]string{"combine_announcements"},
+			db,
+		)
+
+		if err != nil {
+			reply = p.Sprintf("Failed to set combined announcements: %v", err)
+		} else if combine {
+			reply = p.Sprintf("I will announce everyone who shares a meatball day in one message, unless they reach a milestone.")
+		} else {
+			reply = p.Sprintf("I will announce everyone's meatball day in a message of its own.")
+		}
+	} else {
+		reply = p.Sprintf("Nice try.")
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+}
+
+// MeatballWishes sets whether a thread for wishes is started on each of a
+// guild's announcements, and what it's called and what becomes of it.
+func (bot *Bot) MeatballWishes(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	var reply string
+
+	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
+		enabled := i.ApplicationCommandData().Options[0].BoolValue()
+
+		guildSettings := models.GuildSettings{
+			GuildID:       guild.ID,
+			WishesThreads: enabled,
+		}
+		columns := []string{"wishes_threads"}
+
+		// the name and policy are only changed if they're given, so that the
+		// threads can be switched off and on without losing them.
+		var nameErr error
+		if option, ok := discordutils.GetOption(i.ApplicationCommandData().Options, "name"); ok {
+			name := strings.TrimSpace(option.StringValue())
+			if strings.EqualFold(name, "default") {
+				name = ""
+			} else if name != "" {
+				nameErr = validateThreadName(name)
+			}
+			guildSettings.WishesThreadName = name
+			columns = append(columns, "wishes_thread_name")
+		}
+
+		if option, ok := discordutils.GetOption(i.ApplicationCommandData().Options, "archive"); ok {
+			guildSettings.WishesPolicy = option.StringValue()
+			columns = append(columns, "wishes_policy")
+		}
+
+		if errors.Is(nameErr, errThreadNameMention) {
+			reply = p.Sprintf("Thread names can't use {mention}, as mentions don't work in them.")
+		} else if nameErr != nil {
+			reply = templateErrorReply(p, nameErr)
+		} else {
+			err := dal.UpsertGuildSettings(guildSettings, columns, db)
+
+			// describe the policy that's now in place, even if it wasn't given.
+			policy := guildSettings.WishesPolicy
+			if err == nil && policy == "" {
+				var saved *models.GuildSettings
+				saved, err = dal.GetGuildSettings(guild.ID, db)
+				if err == nil {
+					policy = saved.WishesPolicy
+				}
+			}
+
+			if err != nil {
+				reply = p.Sprintf("Failed to set up wishes threads: %v", err)
+			} else if !enabled {
+				reply = p.Sprintf("I will no longer start threads for wishes.")
+			} else {
+				switch policy {
+				case models.WishesLock:
+					reply = p.Sprintf("I will start a thread for wishes on each announcement, and archive and lock it when the meatball day is over.")
+				case models.WishesKeep:
+					reply = p.Sprintf("I will start a thread for wishes on each announcement, and leave it open until it goes quiet.")
+				default:
+					reply = p.Sprintf("I will start a thread for wishes on each announcement, and archive it when the meatball day is over.")
+				}
+			}
+		}
+	} else {
+		reply = p.Sprintf("Nice try.")
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+}
+
+// Parses a hex color code like #e67e22. Returns false if it isn't one.
+func parseColor(color string) (int, bool) {
+	color = strings.TrimPrefix(strings.TrimSpace(color), "#")
+	if len(color) != 6 {
+		return 0, false
+	}
+
+	parsed, err := strconv.ParseUint(color, 16, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return int(parsed), true
+}
+
+// Returns true if the given string is an absolute http or https URL.
+func isWebURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// Joins a heading and the lines of a list into a reply. If that's too long for
+// a message, the list is attached as a text file instead.
+func longList(
+	p locale.Printer,
+	heading string,
+	lines []string,
+	filename string,
+) (string, []*discordgo.File) {
+	reply := heading + strings.Join(lines, "")
+	if utf8.RuneCountInString(reply) <= maxMessageLength {
+		return reply, nil
+	}
+
+	files := []*discordgo.File{
+		{
+			Name:        filename,
+			ContentType: "text/plain",
+			Reader:      strings.NewReader(reply),
+		},
+	}
+
+	return heading + p.Sprintf("\nThere are too many to fit in a message, so they're in the attached file."), files
+}
+
+// Explains why a template was rejected by validateTemplate.
+func templateErrorReply(p locale.Printer, err error) string {
+	var placeholderErr unknownPlaceholderError
+	if errors.As(err, &placeholderErr) {
+		return p.Sprintf(
+			"I don't know the placeholder %v. You can use %v.",
+			placeholderErr.placeholder,
+			p.List(templatePlaceholders),
+		)
+	}
+
+	return p.Sprintf(
+		"Announcements have to be between 1 and %v characters long.",
+		maxTemplateLength,
+	)
+}
+
+// MeatballReminders sets how many days before a meatball day reminders are
+// sent, and where.
+func (bot *Bot) MeatballReminders(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	var reply string
+
+	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
+		reminders, err := parseReminderDays(i.ApplicationCommandData().Options[0].StringValue())
+
+		var channelID string
+		if option, ok := discordutils.GetOption(i.ApplicationCommandData().Options, "channel"); ok {
+			channelID = option.ChannelValue(nil).ID
+		}
+
+		var dayErr reminderDayError
+		if errors.As(err, &dayErr) {
+			reply = p.Sprintf(
+				"%q isn't a number of days between 1 and %v. "+
+					"Give me a comma separated list of days, such as 7,1, or \"off\".",
+				dayErr.field,
+				maxReminderDays,
+			)
+		} else {
+			err := dal.UpsertGuildSettings(
+				models.GuildSettings{
+					GuildID:           guild.ID,
+					ReminderDays:      reminders,
+					ReminderChannelID: channelID,
+				},
+				[]string{"reminder_days", "reminder_channel_id"},
+				db,
+			)
+
+			if err != nil {
+				reply = p.Sprintf("Failed to set reminders: %v", err)
+			} else if reminders == "" {
+				reply = p.Sprintf("I will no longer send reminders.")
+			} else if channelID == "" {
+				reply = p.Sprintf(
+					"I will send reminders %v days before each meatball day "+
+						"in the announcement channel.",
+					reminders,
+				)
+			} else {
+				reply = p.Sprintf(
+					"I will send reminders %v days before each meatball day in <#%v>.",
+					reminders,
+					channelID,
+				)
+			}
+		}
+	} else {
+		reply = p.Sprintf("Nice try.")
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+}
+
+// reminderDayError is returned for a reminder day that isn't a valid number of
+// days. MeatballReminders explains the problem in the member's language.
+type reminderDayError struct {
+	field string
+}
+
+func (err reminderDayError) Error() string {
+	return "invalid reminder day " + err.field
+}
+
+// Parses and normalises a comma separated list of reminder days.
+// "off" gives an empty list.
+func parseReminderDays(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if strings.EqualFold(input, "off") {
+		return "", nil
+	}
+
+	seen := make(map[int]bool)
+	var days []int
+	for _, field := range strings.Split(input, ",") {
+		day, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || day < 1 || day > maxReminderDays {
+			return "", reminderDayError{field: strings.TrimSpace(field)}
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(days)))
+
+	fields := make([]string, len(days))
+	for i, day := range days {
+		fields[i] = strconv.Itoa(day)
+	}
+
+	return strings.Join(fields, ","), nil
+}
+
+// MeatballDigest sets up a regular digest of upcoming meatball days.
+func (bot *Bot) MeatballDigest(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	var reply string
+
+	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
+		schedule := i.ApplicationCommandData().Options[0].StringValue()
+		if schedule == "off" {
+			schedule = ""
+		}
+
+		weekday := time.Monday
+		if option, ok := discordutils.GetOption(i.ApplicationCommandData().Options, "weekday"); ok {
+			weekday = time.Weekday(option.IntValue())
+		}
+
+		var days int64
+		if option, ok := discordutils.GetOption(i.ApplicationCommandData().Options, "days"); ok {
+			days = option.IntValue()
+		}
+
+		if days < 0 || days > maxDigestDays {
+			reply = p.Sprintf(
+				"Digests can cover between 1 and %v days.",
+				maxDigestDays,
+			)
+		} else {
+			err := dal.UpsertGuildSettings(
+				models.GuildSettings{
+					GuildID:        guild.ID,
+					DigestSchedule: schedule,
+					DigestWeekday:  int(weekday),
+					DigestDays:     int(days),
+				},
+				[]string{"digest_schedule", "digest_weekday", "digest_days"},
+				db,
+			)
+
+			if err != nil {
+				reply = p.Sprintf("Failed to set digest schedule: %v", err)
+			} else {
+				switch schedule {
+				case models.DigestWeekly:
+					reply = p.Sprintf(
+						"I will post a digest of upcoming meatball days every %v.",
+						p.Weekday(weekday),
+					)
+				case models.DigestMonthly:
+					reply = p.Sprintf("I will post a digest of upcoming meatball days on the 1st of every month.")
+				default:
+					reply = p.Sprintf("I will no longer post digests of upcoming meatball days.")
+				}
+			}
+		}
+	} else {
+		reply = p.Sprintf("Nice try.")
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+}
+
+// MeatballFeed manages the secret link to a guild's live calendar feed.
+func (bot *Bot) MeatballFeed(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	// feed links are secret, so only the admin gets to see them.
+	discordutils.AckInteractionEphemeral(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	var reply string
+
+	if !discordutils.MemberHasAdminPermissions(guild, i.Member) {
+		reply = p.Sprintf("Nice try.")
+	} else if bot.feeds.server == nil {
+		reply = p.Sprintf("Calendar feeds aren't enabled on this casper.")
+	} else {
+		switch i.ApplicationCommandData().Options[0].Name {
+		case "generate":
+			token, err := newFeedToken()
+			if err == nil {
+				err = dal.UpsertFeedToken(
+					models.FeedToken{
+						GuildID:   guild.ID,
+						TokenHash: hashFeedToken(token),
+					},
+					db,
+				)
+			}
+
+			if err != nil {
+				reply = p.Sprintf("Failed to generate feed link: %v", err)
+			} else {
+				reply = p.Sprintf(
+					"Here's the new feed link, subscribe to it in your calendar app: %v\n"+
+						"Anyone with the link can see the meatball days, so keep it secret. "+
+						"I won't be able to show it to you again.",
+					bot.feeds.url(token),
+				)
+			}
+		case "revoke":
+			err := dal.DeleteFeedToken(guild.ID, db)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				reply = p.Sprintf("There's no feed link to revoke.")
+			} else if err != nil {
+				reply = p.Sprintf("Failed to revoke feed link: %v", err)
+			} else {
+				reply = p.Sprintf("The feed link will no longer work.")
+			}
+		}
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+}
+
+// MeatballImport previews an import of meatball days from an attached file.
+func (bot *Bot) MeatballImport(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteractionEphemeral(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	params := &discordgo.WebhookParams{}
+
+	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
+		data := i.ApplicationCommandData()
+		attachmentID := data.Options[0].Value.(string)
+		attachment := data.Resolved.Attachments[attachmentID]
+
+		rows, err := readImport(p, attachment, guild)
+		if err != nil {
+			params.Content = p.Sprintf(
+				"I couldn't read %v: %v",
+				attachment.Filename,
+				err,
+			)
+		} else {
+			valid := countValidRows(rows)
+
+			params.Content = p.Sprintf(
+				"%v has %v rows I can import and %v with problems. "+
+					"Check the attached preview before confirming.",
+				attachment.Filename,
+				valid,
+				len(rows)-valid,
+			)
+			params.Files = []*discordgo.File{
+				{
+					Name:        "import-preview.txt",
+					ContentType: "text/plain",
+					Reader:      strings.NewReader(importReport(p, rows, false)),
+				},
+			}
+
+			if valid > 0 {
+				id, err := bot.imports.add(&pendingImport{
+					guildID:  guild.ID,
+					userID:   i.Member.User.ID,
+					filename: attachment.Filename,
+					rows:     rows,
+				})
+
+				if err != nil {
+					params.Content = p.Sprintf("Failed to prepare import: %v", err)
+					params.Files = nil
+				} else {
+					params.Components = []discordgo.MessageComponent{
+						discordgo.ActionsRow{
+							Components: []discordgo.MessageComponent{
+								discordgo.Button{
+									Label:    p.Sprintf("Import"),
+									Style:    discordgo.SuccessButton,
+									CustomID: makeCustomID("meatball-import", id, "confirm"),
+								},
+								discordgo.Button{
+									Label:    p.Sprintf("Cancel"),
+									Style:    discordgo.SecondaryButton,
+									CustomID: makeCustomID("meatball-import", id, "cancel"),
+								},
+							},
+						},
+					}
+				}
+			}
+		}
+	} else {
+		params.Content = p.Sprintf("Nice try.")
+	}
+
+	discordutils.SendFollowupComplex(params, i.Interaction, bot.session)
+}
+
+// MeatballImportConfirm carries out or cancels a previewed import.
+func (bot *Bot) MeatballImportConfirm(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	_, args := splitCustomID(i.MessageComponentData().CustomID)
+	if len(args) != 2 {
+		return
+	}
+
+	discordutils.AckComponentInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	var content string
+	var files []*discordgo.File
+	imported := false // if true, triggers a role re-check at the end
+
+	pending, ok := bot.imports.take(args[0])
+	if !ok || pending.userID != i.Member.User.ID || pending.guildID != i.GuildID {
+		content = p.Sprintf("This import has expired. Run the command again to start over.")
+	} else if args[1] != "confirm" {
+		content = p.Sprintf("Cancelled the import of %v.", pending.filename)
+	} else {
+		for i := range pending.rows {
+			row := &pending.rows[i]
+			if row.err == nil {
+				row.err = dal.UpsertMeatballDay(row.meatballDay, db)
+			}
+			if row.err == nil {
+				bot.undos.drop(row.meatballDay.GuildID, row.meatballDay.UserID)
+			}
+		}
+
+		valid := countValidRows(pending.rows)
+		content = p.Sprintf(
+			"Imported %v meatball days from %v, %v rows failed. "+
+				"The attached report has the details.",
+			valid,
+			pending.filename,
+			len(pending.rows)-valid,
+		)
+		files = []*discordgo.File{
+			{
+				Name:        "import-report.txt",
+				ContentType: "text/plain",
+				Reader:      strings.NewReader(importReport(p, pending.rows, true)),
+			},
+		}
+		imported = valid > 0
+	}
+
+	discordutils.EditInteractionResponse(
+		&discordgo.WebhookEdit{
+			Content:    &content,
+			Components: &[]discordgo.MessageComponent{},
+			Files:      files,
+		},
+		i.Interaction,
+		bot.session,
+	)
+
+	if imported {
+		bot.CheckRoles()
+	}
+}
+
+// MeatballExport sends all of the guild's casper data as a file.
+func (bot *Bot) MeatballExport(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	// exports include everyone's meatball days, so only the admin gets them.
+	discordutils.AckInteractionEphemeral(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	params := &discordgo.WebhookParams{}
+
+	if discordutils.MemberHasAdminPermissions(guild, i.Member) {
+		format := i.ApplicationCommandData().Options[0].StringValue()
+
+		var buffer bytes.Buffer
+		guildExport, err := export.Load(guild.ID, db)
+		if err == nil {
+			err = guildExport.Write(&buffer, format)
+		}
+
+		if err != nil {
+			params.Content = p.Sprintf("Failed to export server data: %v", err)
+		} else {
+			params.Content = p.Sprintf("Here's everything I know about this server.")
+			params.Files = []*discordgo.File{
+				{
+					Name:        fmt.Sprintf("casper-%v.%v", guild.ID, format),
+					ContentType: "text/" + format,
+					Reader:      &buffer,
+				},
+			}
+		}
+	} else {
+		params.Content = p.Sprintf("Nice try.")
+	}
+
+	discordutils.SendFollowupComplex(params, i.Interaction, bot.session)
+}
+
+// MeatballSchedule shows when the meatball roles will next be checked.
+func (bot *Bot) MeatballSchedule(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	var reply string
+
+	now := time.Now()
+	wake, ok := bot.scheduler.NextWake(i.GuildID)
+	if !ok {
+		reply = p.Sprintf("I haven't planned the next role check here yet. Try again in a moment.")
+	} else {
+		reply = p.Sprintf(
+			"I'll next check meatball roles here at %v, %v.",
+			wake.Format(prettyDateFormat+" "+prettyTimeFormat+" MST"),
+			p.RelTime(wake, now),
+		)
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+}
+
+// MeatballNext finds the next occurring meatball day, and everyone who shares
+// it.
+func (bot *Bot) MeatballNext(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	userIDs := make([]string, len(guild.Members))
+	for i, member := range guild.Members {
+		userIDs[i] = member.User.ID
+	}
+
+	now := time.Now()
+	nextMeatballDays, err := dal.GetNextMeatballDays(guild.ID, userIDs, now, db)
+
+	var reply string
+
+	if err != nil {
+		reply = p.Sprintf("Failed to get next meatball day: %v", err)
+	} else if len(nextMeatballDays) == 0 {
+		reply = p.Sprintf("There are no meatball days registered yet.")
+	} else {
+		mentions := make([]string, len(nextMeatballDays))
+		for i, meatballDay := range nextMeatballDays {
+			mentions[i] = fmt.Sprintf("<@%v>", meatballDay.UserID)
+		}
+
+		date := nextMeatballDays[0].Date
+		days := calendarDaysBetween(now, date)
+
+		if days == 0 {
+			reply = p.Sprintf(
+				"Today is %v's meatball day!",
+				p.List(mentions),
+			)
+		} else {
+			reply = p.Sprintf(
+				"The next meatball day is %v's on %v, %v.",
+				p.List(mentions),
+				p.Date(date),
+				daysUntil(p, days),
+			)
+		}
+	}
+
+	discordutils.SendFollowup(reply, i.Interaction, bot.session)
+}
+
+// MeatballUpcoming lists the upcoming meatball days.
+func (bot *Bot) MeatballUpcoming(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	var count, days int64
+	options := i.ApplicationCommandData().Options
+	if option, ok := discordutils.GetOption(options, "count"); ok {
+		count = option.IntValue()
+	}
+	if option, ok := discordutils.GetOption(options, "days"); ok {
+		days = option.IntValue()
+	}
+
+	var params *discordgo.WebhookParams
+
+	if count < 0 || days < 0 {
+		params = &discordgo.WebhookParams{
+			Content: p.Sprintf("I can't list a negative number of meatball days."),
+		}
+	} else {
+		content, components := bot.upcomingPage(p, i.GuildID, int(count), int(days), 0, db)
+		params = &discordgo.WebhookParams{
+			Content:         content,
+			Components:      components,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		}
+	}
+
+	discordutils.SendFollowupComplex(params, i.Interaction, bot.session)
+}
+
+// MeatballUpcomingPage shows another page of a meatball-upcoming listing.
+func (bot *Bot) MeatballUpcomingPage(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	_, args := splitCustomID(i.MessageComponentData().CustomID)
+	if len(args) != 3 {
+		return
+	}
+
+	count, _ := strconv.Atoi(args[0])
+	days, _ := strconv.Atoi(args[1])
+	page, _ := strconv.Atoi(args[2])
+
+	p := bot.printer(i, db)
+
+	content, components := bot.upcomingPage(p, i.GuildID, count, days, page, db)
+
+	discordutils.UpdateComponentMessage(
+		&discordgo.InteractionResponseData{
+			Content:         content,
+			Components:      components,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+		i.Interaction,
+		bot.session,
+	)
+}
+
+// MeatballCalendar shows a month of meatball days as a calendar.
+func (bot *Bot) MeatballCalendar(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	now := time.Now()
+	year, month := now.Year(), now.Month()
+	if option, ok := discordutils.GetOption(i.ApplicationCommandData().Options, "month"); ok {
+		month = time.Month(option.IntValue())
+	}
+
+	params := &discordgo.WebhookParams{}
+
+	embed, err := calendarEmbed(p, guild, year, month, db)
+	if err != nil {
+		params.Content = p.Sprintf("Failed to get meatball days: %v", err)
+	} else {
+		params.Embeds = []*discordgo.MessageEmbed{embed}
+		params.Components = calendarButtons(p, year, month)
+	}
+
+	discordutils.SendFollowupComplex(params, i.Interaction, bot.session)
+}
+
+// MeatballCalendarMonth moves a meatball-calendar to another month.
+func (bot *Bot) MeatballCalendarMonth(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	_, args := splitCustomID(i.MessageComponentData().CustomID)
+	if len(args) != 2 {
+		return
+	}
+
+	p := bot.printer(i, db)
+
+	year, _ := strconv.Atoi(args[0])
+	month, _ := strconv.Atoi(args[1])
+
+	data := &discordgo.InteractionResponseData{}
+
+	embed, err := calendarEmbed(p, guild, year, time.Month(month), db)
+	if err != nil {
+		data.Content = p.Sprintf("Failed to get meatball days: %v", err)
+	} else {
+		data.Embeds = []*discordgo.MessageEmbed{embed}
+		data.Components = calendarButtons(p, year, time.Month(month))
+	}
+
+	discordutils.UpdateComponentMessage(data, i.Interaction, bot.session)
+}
+
+// MeatballICS sends the guild's meatball days as an iCalendar file.
+func (bot *Bot) MeatballICS(
+	i *discordgo.InteractionCreate,
+	db *gorm.DB,
+) {
+	discordutils.AckInteraction(i.Interaction, bot.session)
+
+	p := bot.printer(i, db)
+
+	guild, err := bot.session.State.Guild(i.GuildID)
+	if err != nil {
+		log.Panicf(
+			"We have received an interaction from a guild we're not in... " +
+				"this should never happen!",
+		)
+	}
+
+	params := &discordgo.WebhookParams{}
+
+	var calendar *ical.Calendar
+	guildSettings, err := dal.GetGuildSettings(guild.ID, db)
+	if err == nil {
+		calendar, err = guildCalendar(p, guild, *guildSettings, db)
+	}
+
+	var buffer bytes.Buffer
+	if err == nil {
+		err = calendar.Write(&buffer)
+	}
+
+	if err != nil {
+		params.Content = p.Sprintf("Failed to export meatball days: %v", err)
+	} else {
+		params.Content = p.Sprintf(
+			"Here are this server's meatball days. " +
+				"Import the file into your calendar app to never miss one.",
+		)
+		params.Files = []*discordgo.File{
+			{
+				Name:        "meatball-days.ics",
+				ContentType: "text/calendar",
+				Reader:      &buffer,
+			},
+		}
+	}
+
+	discordutils.SendFollowupComplex(params, i.Interaction, bot.session)
+}
+
+// Returns true if year is a believable birth year for a meatball day on the
+// month and day of the given date.
+func validBirthYear(year int, date time.Time) bool {
+	if year < minBirthYear || year > time.Now().Year() {
+		return false
+	}
+
+	// catches 29th february in non-leap years
+	birthDate := time.Date(year, date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return birthDate.Month() == date.Month()
+}
+
+// Formats the month and day of date, followed by year if it isn't 0.
+func prettyMeatballDay(p locale.Printer, date time.Time, year int) string {
+	pretty := p.Date(date)
+	if year != 0 {
+		pretty = p.Sprintf("%v, %v", pretty, year)
+	}
+	return pretty
+}
+
+// Tells a user when they can next change their meatball day.
+func meatballCooldownReply(p locale.Printer, lastUse *time.Time) string {
+	nextUse := lastUse.Add(meatballSaveCooldown)
+	return p.Sprintf(
+		"You last changed your meatball day on %v at %v. "+
+			"You can change it again %v.",
+		lastUse.Format(prettyDateFormat),
+		lastUse.Format(prettyTimeFormat),
+		p.RelTime(nextUse, time.Now()),
+	)
 }
 
 func (bot *Bot) userCanChangeMeatballDay(uid userID) (bool, *time.Time) {
@@ -0,0 +1,51 @@
+package bot
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseReminderDays(t *testing.T) {
+	tests := []struct {
+		input     string
+		want      string
+		wantErr   bool
+		wantField string // the field reported as invalid
+	}{
+		{input: "7", want: "7"},
+		{input: "1,7", want: "7,1"},
+		{input: " 7 , 1 ,7 ", want: "7,1"},
+		{input: "365,1,14", want: "365,14,1"},
+		{input: "off", want: ""},
+		{input: " OFF ", want: ""},
+		{input: "0", wantErr: true, wantField: "0"},
+		{input: "7,-1", wantErr: true, wantField: "-1"},
+		{input: "7,366", wantErr: true, wantField: "366"},
+		{input: "7,,1", wantErr: true, wantField: ""},
+		{input: "a week", wantErr: true, wantField: "a week"},
+		{input: "", wantErr: true, wantField: ""},
+	}
+
+	for _, test := range tests {
+		got, err := parseReminderDays(test.input)
+
+		if test.wantErr {
+			var dayErr reminderDayError
+			if !errors.As(err, &dayErr) {
+				t.Errorf("parseReminderDays(%q) error = %v, want a reminderDayError", test.input, err)
+			} else if dayErr.field != test.wantField {
+				t.Errorf("parseReminderDays(%q) rejected %q, want %q", test.input, dayErr.field, test.wantField)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("parseReminderDays(%q) error = %v", test.input, err)
+			continue
+		}
+
+		if got != test.want {
+			t.Errorf("parseReminderDays(%q) = %q, want %q", test.input, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package bot
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// the formats documented for /meatball-save, which need no confirmation.
+	exactDatePattern = regexp.MustCompile(`^(\d{1,2})-(\d{1,2})$`)
+	isoDatePattern   = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})$`)
+
+	numericDatePattern = regexp.MustCompile(
+		`^(\d{1,2})[/.](\d{1,2})(?:[/.](\d{4}))?$`,
+	)
+	dayMonthPattern = regexp.MustCompile(
+		`^(\d{1,2})(?:st|nd|rd|th)?(?:\s+of)?\s+([a-z]+)\.?(?:,?\s+(\d{4}))?$`,
+	)
+	monthDayPattern = regexp.MustCompile(
+		`^([a-z]+)\.?\s+(\d{1,2})(?:st|nd|rd|th)?(?:,?\s+(\d{4}))?$`,
+	)
+)
+
+var errUnknownDate = errors.New("unrecognised date")
+
+// parsedMeatballDay is a meatball day read from user input.
+type parsedMeatballDay struct {
+	date  time.Time // only the month and day are meaningful
+	year  int       // 0 if the input didn't include one
+	exact bool      // true if the input was in an unambiguous documented format
+}
+
+// Reads a meatball day from the many ways people write dates. Numeric dates
+// such as 2/1 are read day first if dayFirst is true, otherwise month first.
+func parseMeatballDay(input string, dayFirst bool) (parsedMeatballDay, error) {
+	input = strings.ToLower(strings.Join(strings.Fields(input), " "))
+
+	if matches := exactDatePattern.FindStringSubmatch(input); matches != nil {
+		return newParsedMeatballDay(matches[1], matches[2], "", true)
+	}
+
+	if matches := isoDatePattern.FindStringSubmatch(input); matches != nil {
+		return newParsedMeatballDay(matches[2], matches[3], matches[1], true)
+	}
+
+	if matches := numericDatePattern.FindStringSubmatch(input); matches != nil {
+		if dayFirst {
+			return newParsedMeatballDay(matches[2], matches[1], matches[3], false)
+		}
+		return newParsedMeatballDay(matches[1], matches[2], matches[3], false)
+	}
+
+	if matches := dayMonthPattern.FindStringSubmatch(input); matches != nil {
+		if month, ok := parseMonthName(matches[2]); ok {
+			return newParsedMeatballDay(month, matches[1], matches[3], false)
+		}
+	}
+
+	if matches := monthDayPattern.FindStringSubmatch(input); matches != nil {
+		if month, ok := parseMonthName(matches[1]); ok {
+			return newParsedMeatballDay(month, matches[2], matches[3], false)
+		}
+	}
+
+	return parsedMeatballDay{}, errUnknownDate
+}
+
+// Builds a parsed meatball day from its numeric parts, checking that the day
+// exists in the month. year may be empty.
+func newParsedMeatballDay(
+	month string,
+	day string,
+	year string,
+	exact bool,
+) (parsedMeatballDay, error) {
+	monthNumber, _ := strconv.Atoi(month)
+	dayNumber, _ := strconv.Atoi(day)
+
+	// 2000 is a leap year, so 29th february is allowed through.
+	date := time.Date(2000, time.Month(monthNumber), dayNumber, 0, 0, 0, 0, time.UTC)
+	if monthNumber < 1 || monthNumber > 12 || date.Day() != dayNumber {
+		return parsedMeatballDay{}, errUnknownDate
+	}
+
+	parsed := parsedMeatballDay{date: date, exact: exact}
+	if year != "" {
+		parsed.year, _ = strconv.Atoi(year)
+	}
+
+	return parsed, nil
+}
+
+// Returns the number of the month with the given name, which may be shortened
+// to as few as three letters.
+func parseMonthName(name string) (string, bool) {
+	if len(name) < 3 {
+		return "", false
+	}
+
+	for month := time.January; month <= time.December; month++ {
+		if strings.HasPrefix(strings.ToLower(month.String()), name) {
+			return strconv.Itoa(int(month)), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseMeatballDay(t *testing.T) {
+	tests := []struct {
+		input     string
+		dayFirst  bool
+		wantMonth time.Month
+		wantDay   int
+		wantYear  int
+		wantExact bool
+		wantErr   bool
+	}{
+		{input: "01-02", wantMonth: time.January, wantDay: 2, wantExact: true},
+		{input: "1-2", wantMonth: time.January, wantDay: 2, wantExact: true},
+		{input: "12-31", wantMonth: time.December, wantDay: 31, wantExact: true},
+		{input: "01-02", dayFirst: true, wantMonth: time.January, wantDay: 2, wantExact: true},
+		{input: "02-29", wantMonth: time.February, wantDay: 29, wantExact: true},
+		{input: "1990-01-02", wantMonth: time.January, wantDay: 2, wantYear: 1990, wantExact: true},
+		{input: "2000-2-29", wantMonth: time.February, wantDay: 29, wantYear: 2000, wantExact: true},
+		{input: "2/1", wantMonth: time.February, wantDay: 1},
+		{input: "2/1", dayFirst: true, wantMonth: time.January, wantDay: 2},
+		{input: "2.1.1990", dayFirst: true, wantMonth: time.January, wantDay: 2, wantYear: 1990},
+		{input: "2 Jan", wantMonth: time.January, wantDay: 2},
+		{input: "2nd of January 1990", wantMonth: time.January, wantDay: 2, wantYear: 1990},
+		{input: "January 2nd", wantMonth: time.January, wantDay: 2},
+		{input: "  jan.   2, 1990 ", wantMonth: time.January, wantDay: 2, wantYear: 1990},
+		{input: "feb 29", wantMonth: time.February, wantDay: 29},
+		{input: "02-30", wantErr: true},
+		{input: "13-01", wantErr: true},
+		{input: "00-10", wantErr: true},
+		{input: "001-02", wantErr: true},
+		{input: "31/4", dayFirst: true, wantErr: true},
+		{input: "2 ja", wantErr: true},
+		{input: "2 smarch", wantErr: true},
+		{input: "tomorrow", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, test := range tests {
+		parsed, err := parseMeatballDay(test.input, test.dayFirst)
+
+		if test.wantErr {
+			if !errors.Is(err, errUnknownDate) {
+				t.Errorf("parseMeatballDay(%q, %v) error = %v, want %v", test.input, test.dayFirst, err, errUnknownDate)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("parseMeatballDay(%q, %v) error = %v", test.input, test.dayFirst, err)
+			continue
+		}
+
+		if parsed.date.Month() != test.wantMonth ||
+			parsed.date.Day() != test.wantDay ||
+			parsed.year != test.wantYear ||
+			parsed.exact != test.wantExact {
+			t.Errorf(
+				"parseMeatballDay(%q, %v) = %v %v, year %v, exact %v; want %v %v, year %v, exact %v",
+				test.input, test.dayFirst,
+				parsed.date.Month(), parsed.date.Day(), parsed.year, parsed.exact,
+				test.wantMonth, test.wantDay, test.wantYear, test.wantExact,
+			)
+		}
+	}
+}
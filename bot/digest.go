@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"casper/dal"
+	"casper/locale"
+	"casper/models"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+)
+
+// Posts the guild's digest of upcoming meatball days, if one is due today.
+func postDigest(
+	p locale.Printer,
+	guild *discordgo.Guild,
+	guildSettings models.GuildSettings,
+	now time.Time,
+	session *discordgo.Session,
+	db *gorm.DB,
+) {
+	today := now.In(guildSettings.Location())
+
+	period := guildSettings.DigestPeriod(today)
+	if period == 0 {
+		return
+	}
+
+	meatballChannel, err := dal.GetMeatballChannel(guild.ID, db)
+	if err != nil {
+		log.Printf("Can't post digest in %v: %v", guild.Name, err)
+		return
+	}
+
+	sentDigest := models.SentDigest{
+		GuildID: guild.ID,
+		Date:    today.Format(prettyDateFormat),
+	}
+
+	claimed, err := dal.ClaimDigest(sentDigest, db)
+	if err != nil {
+		log.Printf("Failed to check whether a digest was posted in %v: %v", guild.Name, err)
+		return
+	}
+
+	if !claimed {
+		return
+	}
+
+	content, err := digestContent(p, guild, period, now, db)
+	if err == nil && content != "" {
+		_, err = session.ChannelMessageSendComplex(
+			meatballChannel.ChannelID,
+			&discordgo.MessageSend{
+				Content:         content,
+				AllowedMentions: &discordgo.MessageAllowedMentions{},
+			},
+		)
+	}
+
+	if err != nil {
+		log.Printf("Failed to post digest in %v: %v", guild.Name, err)
+		if err := dal.ReleaseDigest(sentDigest, db); err != nil {
+			log.Printf("Failed to release digest in %v: %v", guild.Name, err)
+		}
+	}
+}
+
+// Lists the meatball days of the guild's members in the given number of days.
+// Returns an empty string if there aren't any.
+func digestContent(
+	p locale.Printer,
+	guild *discordgo.Guild,
+	period int,
+	now time.Time,
+	db *gorm.DB,
+) (string, error) {
+	lines, err := upcomingMeatballLines(p, guild, 0, period, now, db)
+	if err != nil {
+		return "", err
+	}
+
+	if len(lines) == 0 {
+		return "", nil
+	}
+
+	return p.Sprintf(
+		"**Meatball days in the next %v days:**\n%v",
+		period,
+		strings.Join(lines, "\n"),
+	), nil
+}
+
+// Counts the calendar days from t until date, in date's time zone.
+func calendarDaysBetween(t time.Time, date time.Time) int {
+	fromYear, fromMonth, fromDay := t.In(date.Location()).Date()
+	toYear, toMonth, toDay := date.Date()
+
+	from := time.Date(fromYear, fromMonth, fromDay, 0, 0, 0, 0, time.UTC)
+	to := time.Date(toYear, toMonth, toDay, 0, 0, 0, 0, time.UTC)
+
+	return int(to.Sub(from).Hours() / 24)
+}
@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"casper/dal"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const feedPath = "/feed/"
+const feedTokenBytes = 32
+
+// Feeds are small, so slow clients are cut off rather than left holding
+// connections open.
+const feedReadTimeout = 10 * time.Second
+const feedWriteTimeout = 30 * time.Second
+
+// feedServer serves live iCalendar feeds of guilds' meatball days.
+type feedServer struct {
+	server  *http.Server
+	baseURL string
+}
+
+// Configures a server for meatball day calendar feeds on the given address, or
+// none if addr is empty. baseURL is where the server can be reached publicly,
+// and is used to build links to feeds.
+func newFeedServer(addr string, baseURL string, handler http.HandlerFunc) *feedServer {
+	if addr == "" {
+		return &feedServer{}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(feedPath, handler)
+
+	return &feedServer{
+		server: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: feedReadTimeout,
+			ReadTimeout:       feedReadTimeout,
+			WriteTimeout:      feedWriteTimeout,
+		},
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Starts serving feeds, if a server was configured.
+func (feeds *feedServer) start() {
+	if feeds.server == nil {
+		return
+	}
+
+	go func() {
+		err := feeds.server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to serve feeds: %v", err)
+		}
+	}()
+
+	log.Printf("Serving feeds on %v.", feeds.server.Addr)
+}
+
+// Serves the calendar feed of the guild the token in the request path belongs
+// to.
+func (bot *Bot) handleFeed(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, feedPath), ".ics")
+
+	feedToken, err := dal.GetFeedToken(hashFeedToken(token), bot.db)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		log.Printf("Failed to look up feed token: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	guild, err := bot.session.State.Guild(feedToken.GuildID)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	guildSettings, err := dal.GetGuildSettings(guild.ID, bot.db)
+	if err != nil {
+		log.Printf("Failed to get settings for %v: %v", guild.Name, err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	calendar, err := guildCalendar(
+		guildPrinter(guild, *guildSettings),
+		guild,
+		*guildSettings,
+		bot.db,
+	)
+	if err != nil {
+		log.Printf("Failed to build calendar for %v: %v", guild.Name, err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	if err := calendar.Write(w); err != nil {
+		log.Printf("Failed to write calendar for %v: %v", guild.Name, err)
+	}
+}
+
+// Builds the public link to the feed with the given token.
+func (feeds *feedServer) url(token string) string {
+	return feeds.baseURL + feedPath + token + ".ics"
+}
+
+// Generates a new unguessable feed token.
+func newFeedToken() (string, error) {
+	token := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// Hashes a feed token for storage, so that the database alone can't be used to
+// read feeds.
+func hashFeedToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
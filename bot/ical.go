@@ -0,0 +1,69 @@
+package bot
+
+import (
+	"casper/dal"
+	"casper/ical"
+	"casper/locale"
+	"casper/models"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+)
+
+// Builds a calendar of the guild members' meatball days, leaving out anyone
+// who has asked to be kept private.
+func guildCalendar(
+	p locale.Printer,
+	guild *discordgo.Guild,
+	guildSettings models.GuildSettings,
+	db *gorm.DB,
+) (*ical.Calendar, error) {
+	meatballDays, err := dal.GetUpcomingMeatballDays(guild.ID, time.Now(), db)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]*discordgo.Member)
+	for _, member := range guild.Members {
+		members[member.User.ID] = member
+	}
+
+	leapDay := ical.LeapDayFebruary28
+	switch guildSettings.LeapDayPolicy {
+	case models.LeapDayMarch1:
+		leapDay = ical.LeapDayMarch1
+	case models.LeapDaySkip:
+		leapDay = ical.LeapDaySkip
+	}
+
+	calendar := ical.Calendar{
+		Name: p.Sprintf("Meatball days in %v", guild.Name),
+	}
+
+	for _, meatballDay := range meatballDays {
+		member, ok := members[meatballDay.UserID]
+		if !ok || meatballDay.Private {
+			continue
+		}
+
+		calendar.Events = append(calendar.Events, ical.Event{
+			UID:     fmt.Sprintf("%v-%v@casper", guild.ID, member.User.ID),
+			Summary: p.Sprintf("%v's meatball day", displayName(member)),
+			Month:   time.Month(meatballDay.Month),
+			Day:     int(meatballDay.Day),
+			LeapDay: leapDay,
+		})
+	}
+
+	return &calendar, nil
+}
+
+// Returns the name the member goes by in their guild.
+func displayName(member *discordgo.Member) string {
+	if member.Nick != "" {
+		return member.Nick
+	}
+	return member.User.Username
+}
@@ -0,0 +1,386 @@
+package bot
+
+import (
+	"bytes"
+	"casper/locale"
+	"casper/models"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const maxImportBytes = 1 << 20
+const importExpiry = 15 * time.Minute
+
+// importClient downloads import files, giving up on any that take too long
+// rather than holding up the interaction.
+var importClient = &http.Client{Timeout: 30 * time.Second}
+
+var snowflakePattern = regexp.MustCompile(`^\d+$`)
+
+// importRow is a single row of an import file, and what became of it.
+type importRow struct {
+	line        int
+	user        string
+	meatballDay models.MeatballDay
+	err         error
+}
+
+// pendingImport is an import that has been previewed but not yet confirmed.
+type pendingImport struct {
+	guildID  string
+	userID   string
+	filename string
+	rows     []importRow
+	expires  time.Time
+}
+
+// importStore holds pending imports until they are confirmed or cancelled.
+type importStore struct {
+	mu      sync.Mutex
+	imports map[string]*pendingImport
+}
+
+func newImportStore() *importStore {
+	return &importStore{imports: make(map[string]*pendingImport)}
+}
+
+// Stores the given import and returns the ID to confirm it with.
+func (store *importStore) add(pending *pendingImport) (string, error) {
+	idBytes := make([]byte, 8)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(idBytes)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	now := time.Now()
+	for existingID, existing := range store.imports {
+		if now.After(existing.expires) {
+			delete(store.imports, existingID)
+		}
+	}
+
+	pending.expires = now.Add(importExpiry)
+	store.imports[id] = pending
+
+	return id, nil
+}
+
+// Removes and returns the import with the given ID, if it hasn't expired.
+func (store *importStore) take(id string) (*pendingImport, bool) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	pending, ok := store.imports[id]
+	if !ok {
+		return nil, false
+	}
+
+	delete(store.imports, id)
+	return pending, time.Now().Before(pending.expires)
+}
+
+// Downloads an attached import file and validates each of its rows against the
+// guild's members.
+func readImport(
+	p locale.Printer,
+	attachment *discordgo.MessageAttachment,
+	guild *discordgo.Guild,
+) ([]importRow, error) {
+	if attachment.Size > maxImportBytes {
+		return nil, errors.New(p.Sprintf("the file is bigger than %v bytes", maxImportBytes))
+	}
+
+	response, err := importClient.Get(attachment.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.New(p.Sprintf("downloading the file failed: %v", response.Status))
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(response.Body, maxImportBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []importRow
+	switch strings.ToLower(path.Ext(attachment.Filename)) {
+	case ".csv":
+		rows, err = parseImportCSV(p, data)
+	case ".json":
+		rows, err = parseImportJSON(p, data)
+	default:
+		return nil, errors.New(p.Sprintf("only .csv and .json files can be imported"))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	validateImportRows(p, rows, guild)
+
+	return rows, nil
+}
+
+// Parses CSV rows of user, date and an optional year. A header row is allowed.
+func parseImportCSV(p locale.Printer, data []byte) ([]importRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []importRow
+	for i, record := range records {
+		line := i + 1
+
+		if i == 0 && len(record) > 0 && strings.EqualFold(record[0], "user") {
+			continue
+		}
+
+		if len(record) < 2 {
+			rows = append(rows, importRow{
+				line: line,
+				err:  errors.New(p.Sprintf("expected at least a user and a date")),
+			})
+			continue
+		}
+
+		year := ""
+		if len(record) > 2 {
+			year = record[2]
+		}
+
+		rows = append(rows, parseImportRow(p, line, record[0], record[1], year))
+	}
+
+	return rows, nil
+}
+
+// Parses a JSON array of objects with user, date and optional year fields.
+func parseImportJSON(p locale.Printer, data []byte) ([]importRow, error) {
+	var records []struct {
+		User string `json:"user"`
+		Date string `json:"date"`
+		Year int    `json:"year"`
+	}
+
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+
+	rows := make([]importRow, len(records))
+	for i, record := range records {
+		year := ""
+		if record.Year != 0 {
+			year = strconv.Itoa(record.Year)
+		}
+		rows[i] = parseImportRow(p, i+1, record.User, record.Date, year)
+	}
+
+	return rows, nil
+}
+
+// Parses the date and year of a row. Dates may be MM-DD, or YYYY-MM-DD in
+// place of a separate year.
+func parseImportRow(
+	p locale.Printer,
+	line int,
+	user string,
+	date string,
+	year string,
+) importRow {
+	row := importRow{line: line, user: strings.TrimSpace(user)}
+
+	date = strings.TrimSpace(date)
+	parsed, err := time.Parse(MeatballDayExample, date)
+	if err != nil {
+		parsed, err = time.Parse(prettyDateFormat, date)
+		if err != nil {
+			row.err = errors.New(p.Sprintf(
+				"%q isn't a date in %v or YYYY-MM-DD format",
+				date,
+				MeatballDayFormat,
+			))
+			return row
+		}
+		row.meatballDay.Year = uint(parsed.Year())
+	}
+
+	if year = strings.TrimSpace(year); year != "" {
+		parsedYear, err := strconv.Atoi(year)
+		if err != nil {
+			row.err = errors.New(p.Sprintf("%q isn't a year", year))
+			return row
+		}
+		row.meatballDay.Year = uint(parsedYear)
+	}
+
+	if row.meatballDay.Year != 0 && !validBirthYear(int(row.meatballDay.Year), parsed) {
+		row.err = errors.New(p.Sprintf(
+			"%v isn't a valid year for a meatball day on %v",
+			row.meatballDay.Year,
+			p.Date(parsed),
+		))
+		return row
+	}
+
+	row.meatballDay.Month = uint(parsed.Month())
+	row.meatballDay.Day = uint(parsed.Day())
+
+	return row
+}
+
+// Resolves the user of each valid row to a guild member, and rejects rows for
+// users that appear more than once.
+func validateImportRows(p locale.Printer, rows []importRow, guild *discordgo.Guild) {
+	byID := make(map[string]*discordgo.Member)
+	byName := make(map[string][]*discordgo.Member)
+	for _, member := range guild.Members {
+		byID[member.User.ID] = member
+
+		names := []string{
+			member.User.Username,
+			member.User.Username + "#" + member.User.Discriminator,
+		}
+		if member.Nick != "" {
+			names = append(names, member.Nick)
+		}
+		for _, name := range names {
+			name = strings.ToLower(name)
+			byName[name] = append(byName[name], member)
+		}
+	}
+
+	seen := make(map[string]int)
+
+	for i := range rows {
+		row := &rows[i]
+		if row.err != nil {
+			continue
+		}
+
+		var member *discordgo.Member
+		if snowflakePattern.MatchString(row.user) {
+			member = byID[row.user]
+		} else if matches := uniqueMembers(byName[strings.ToLower(row.user)]); len(matches) == 1 {
+			member = matches[0]
+		} else if len(matches) > 1 {
+			row.err = errors.New(p.Sprintf(
+				"%q matches more than one member, use their ID instead",
+				row.user,
+			))
+			continue
+		}
+
+		if member == nil {
+			row.err = errors.New(p.Sprintf("%q isn't a member of this server", row.user))
+			continue
+		}
+
+		if line, ok := seen[member.User.ID]; ok {
+			row.err = errors.New(p.Sprintf(
+				"%v already appears on line %v",
+				member.User.Username,
+				line,
+			))
+			continue
+		}
+		seen[member.User.ID] = row.line
+
+		row.meatballDay.GuildID = guild.ID
+		row.meatballDay.UserID = member.User.ID
+	}
+}
+
+// Removes duplicates from a list of members, e.g. if a nickname matches the
+// member's own username.
+func uniqueMembers(members []*discordgo.Member) []*discordgo.Member {
+	seen := make(map[string]bool)
+	var unique []*discordgo.Member
+	for _, member := range members {
+		if !seen[member.User.ID] {
+			seen[member.User.ID] = true
+			unique = append(unique, member)
+		}
+	}
+	return unique
+}
+
+// Describes what will happen to each row of an import, or what has happened
+// once it's done.
+func importReport(p locale.Printer, rows []importRow, done bool) string {
+	var report strings.Builder
+	for _, row := range rows {
+		if row.err != nil {
+			report.WriteString(p.Sprintf("line %v: failed: %v", row.line, row.err))
+			report.WriteString("\n")
+			continue
+		}
+
+		date := prettyMeatballDay(
+			p,
+			time.Date(
+				2000, // a leap year, so that 29th february stays put
+				time.Month(row.meatballDay.Month),
+				int(row.meatballDay.Day),
+				0, 0, 0, 0,
+				time.UTC,
+			),
+			int(row.meatballDay.Year),
+		)
+
+		if done {
+			report.WriteString(p.Sprintf(
+				"line %v: imported %v (%v) as %v",
+				row.line,
+				row.user,
+				row.meatballDay.UserID,
+				date,
+			))
+		} else {
+			report.WriteString(p.Sprintf(
+				"line %v: will import %v (%v) as %v",
+				row.line,
+				row.user,
+				row.meatballDay.UserID,
+				date,
+			))
+		}
+		report.WriteString("\n")
+	}
+	return report.String()
+}
+
+// Counts the rows of an import that are valid.
+func countValidRows(rows []importRow) int {
+	valid := 0
+	for _, row := range rows {
+		if row.err == nil {
+			valid++
+		}
+	}
+	return valid
+}
@@ -0,0 +1,144 @@
+package bot
+
+import (
+	"casper/locale"
+	"testing"
+)
+
+func TestParseImportRow(t *testing.T) {
+	p := locale.NewPrinter("en")
+
+	tests := []struct {
+		date      string
+		year      string
+		wantMonth uint
+		wantDay   uint
+		wantYear  uint
+		wantErr   bool
+	}{
+		{date: "01-02", wantMonth: 1, wantDay: 2},
+		{date: " 12-31 ", wantMonth: 12, wantDay: 31},
+		{date: "01-02", year: "1990", wantMonth: 1, wantDay: 2, wantYear: 1990},
+		{date: "1990-01-02", wantMonth: 1, wantDay: 2, wantYear: 1990},
+		{date: "1990-01-02", year: "1991", wantMonth: 1, wantDay: 2, wantYear: 1991},
+		{date: "02-29", wantMonth: 2, wantDay: 29},
+		{date: "02-29", year: "2000", wantMonth: 2, wantDay: 29, wantYear: 2000},
+		{date: "2000-02-29", wantMonth: 2, wantDay: 29, wantYear: 2000},
+		{date: "02-29", year: "2001", wantErr: true},
+		{date: "2001-02-29", wantErr: true},
+		{date: "02-30", wantErr: true},
+		{date: "13-01", wantErr: true},
+		{date: "2 Jan", wantErr: true},
+		{date: "01-02", year: "nineteen", wantErr: true},
+		{date: "01-02", year: "1066", wantErr: true},
+		{date: "", wantErr: true},
+	}
+
+	for _, test := range tests {
+		row := parseImportRow(p, 1, " someone ", test.date, test.year)
+
+		if row.user != "someone" {
+			t.Errorf("parseImportRow(%q, %q) user = %q, want %q", test.date, test.year, row.user, "someone")
+		}
+
+		if test.wantErr {
+			if row.err == nil {
+				t.Errorf("parseImportRow(%q, %q) has no error", test.date, test.year)
+			}
+			continue
+		}
+
+		if row.err != nil {
+			t.Errorf("parseImportRow(%q, %q) error = %v", test.date, test.year, row.err)
+			continue
+		}
+
+		meatballDay := row.meatballDay
+		if meatballDay.Month != test.wantMonth || meatballDay.Day != test.wantDay || meatballDay.Year != test.wantYear {
+			t.Errorf(
+				"parseImportRow(%q, %q) = %v-%v, year %v; want %v-%v, year %v",
+				test.date, test.year,
+				meatballDay.Month, meatballDay.Day, meatballDay.Year,
+				test.wantMonth, test.wantDay, test.wantYear,
+			)
+		}
+	}
+}
+
+func TestParseImportCSV(t *testing.T) {
+	p := locale.NewPrinter("en")
+
+	data := "user,date,year\n" +
+		"alice,01-02\n" +
+		"bob, 2000-02-29\n" +
+		"carol,03-04,1990\n" +
+		"dave\n" +
+		"erin,nope\n"
+
+	rows, err := parseImportCSV(p, []byte(data))
+	if err != nil {
+		t.Fatalf("parseImportCSV error = %v", err)
+	}
+
+	want := []struct {
+		line    int
+		user    string
+		year    uint
+		wantErr bool
+	}{
+		{line: 2, user: "alice"},
+		{line: 3, user: "bob", year: 2000},
+		{line: 4, user: "carol", year: 1990},
+		{line: 5, wantErr: true},
+		{line: 6, user: "erin", wantErr: true},
+	}
+
+	if len(rows) != len(want) {
+		t.Fatalf("parseImportCSV returned %v rows, want %v", len(rows), len(want))
+	}
+
+	for i, row := range rows {
+		if row.line != want[i].line || row.user != want[i].user || (row.err != nil) != want[i].wantErr {
+			t.Errorf(
+				"row %v = line %v, user %q, error %v; want line %v, user %q, error %v",
+				i, row.line, row.user, row.err, want[i].line, want[i].user, want[i].wantErr,
+			)
+		}
+		if row.err == nil && row.meatballDay.Year != want[i].year {
+			t.Errorf("row %v year = %v, want %v", i, row.meatballDay.Year, want[i].year)
+		}
+	}
+}
+
+func TestParseImportJSON(t *testing.T) {
+	p := locale.NewPrinter("en")
+
+	data := `[
+		{"user": "alice", "date": "01-02"},
+		{"user": "bob", "date": "02-29", "year": 2000},
+		{"user": "carol", "date": "02-29", "year": 2001}
+	]`
+
+	rows, err := parseImportJSON(p, []byte(data))
+	if err != nil {
+		t.Fatalf("parseImportJSON error = %v", err)
+	}
+
+	if len(rows) != 3 {
+		t.Fatalf("parseImportJSON returned %v rows, want 3", len(rows))
+	}
+
+	if rows[0].err != nil || rows[0].meatballDay.Year != 0 {
+		t.Errorf("alice = year %v, error %v; want no year", rows[0].meatballDay.Year, rows[0].err)
+	}
+	if rows[1].err != nil || rows[1].meatballDay.Year != 2000 {
+		t.Errorf("bob = year %v, error %v; want 2000", rows[1].meatballDay.Year, rows[1].err)
+	}
+	if rows[2].err == nil {
+		t.Errorf("carol has no error, but 2001 has no 29th february")
+	}
+
+	if _, err := parseImportJSON(p, []byte(`{"user": "alice"}`)); err == nil {
+		t.Errorf("parseImportJSON accepted an object instead of an array")
+	}
+}
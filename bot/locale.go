@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"casper/dal"
+	"casper/locale"
+	"casper/models"
+
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+)
+
+// Returns a printer for messages posted to the given guild, in the language
+// the guild has chosen, or its discord locale if it hasn't chosen one.
+func guildPrinter(
+	guild *discordgo.Guild,
+	guildSettings models.GuildSettings,
+) locale.Printer {
+	if locale.Supported(guildSettings.Language) {
+		return locale.NewPrinter(guildSettings.Language)
+	}
+
+	code, _ := locale.Match(guild.PreferredLocale)
+	return locale.NewPrinter(code)
+}
+
+// Returns a printer for replies to the given interaction, in the user's own
+// language if casper speaks it, otherwise the guild's language.
+func (bot *Bot) printer(i *discordgo.InteractionCreate, db *gorm.DB) locale.Printer {
+	if code, ok := locale.Match(string(i.Locale)); ok {
+		return locale.NewPrinter(code)
+	}
+
+	// there are no guild settings to follow in DMs.
+	if i.GuildID == "" {
+		return locale.NewPrinter(locale.English)
+	}
+
+	guildSettings, err := dal.GetGuildSettings(i.GuildID, db)
+	if err != nil {
+		return locale.NewPrinter(locale.English)
+	}
+
+	if locale.Supported(guildSettings.Language) {
+		return locale.NewPrinter(guildSettings.Language)
+	}
+
+	if i.GuildLocale != nil {
+		code, _ := locale.Match(string(*i.GuildLocale))
+		return locale.NewPrinter(code)
+	}
+
+	return locale.NewPrinter(locale.English)
+}
+
+// languageAuto is the choice of language that follows the guild's discord
+// locale.
+const languageAuto = "auto"
+
+// Returns the choices for the guild language option.
+func languageChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := []*discordgo.ApplicationCommandOptionChoice{
+		{Name: "Automatic (server's Discord language)", Value: languageAuto},
+	}
+	for _, code := range locale.Languages() {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  locale.Name(code),
+			Value: code,
+		})
+	}
+	return choices
+}
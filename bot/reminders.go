@@ -0,0 +1,226 @@
+package bot
+
+import (
+	"casper/dal"
+	"casper/locale"
+	"casper/models"
+	"log"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+)
+
+// Sends the guild's reminder messages, and DMs to followers, for every meatball
+// day that is one of the guild's reminder periods away.
+func sendReminders(
+	p locale.Printer,
+	guild *discordgo.Guild,
+	guildSettings models.GuildSettings,
+	now time.Time,
+	session *discordgo.Session,
+	db *gorm.DB,
+) {
+	reminders := guildSettings.Reminders()
+	if len(reminders) == 0 {
+		return
+	}
+
+	var meatballDays []models.MeatballDay
+	err := db.Where(&models.MeatballDay{GuildID: guild.ID}).Find(&meatballDays).Error
+	if err != nil {
+		log.Printf("Failed to find meatball days for guild %v: %v", guild.Name, err)
+		return
+	}
+
+	members := make(map[string]*discordgo.Member)
+	for _, member := range guild.Members {
+		members[member.User.ID] = member
+	}
+
+	reminderChannelID := guildSettings.ReminderChannelID
+	if reminderChannelID == "" {
+		if meatballChannel, err := dal.GetMeatballChannel(guild.ID, db); err == nil {
+			reminderChannelID = meatballChannel.ChannelID
+		}
+	}
+
+	for _, meatballDay := range meatballDays {
+		member, ok := members[meatballDay.UserID]
+		if !ok {
+			continue
+		}
+
+		for _, daysBefore := range reminders {
+			date, ok := reminderDue(meatballDay, daysBefore, guildSettings, now)
+			if !ok {
+				continue
+			}
+
+			sentReminder := models.SentReminder{
+				GuildID:    guild.ID,
+				UserID:     member.User.ID,
+				Year:       date.Year(),
+				DaysBefore: daysBefore,
+			}
+
+			if reminderChannelID != "" {
+				sendReminderOnce(sentReminder, db, func() error {
+					return remindChannel(p, member, date, daysBefore, reminderChannelID, session)
+				})
+			}
+
+			followers, err := dal.GetMeatballFollowers(guild.ID, member.User.ID, db)
+			if err != nil {
+				log.Printf(
+					"Failed to find followers of %v in %v: %v",
+					member.User.Username,
+					guild.Name,
+					err,
+				)
+				continue
+			}
+
+			for _, followerID := range followers {
+				if _, ok := members[followerID]; !ok {
+					continue
+				}
+
+				sentReminder.RecipientID = followerID
+				sendReminderOnce(sentReminder, db, func() error {
+					return remindFollower(p, guild, member, date, daysBefore, followerID, session)
+				})
+			}
+		}
+	}
+}
+
+// Finds the occurrence of the given meatball day that is exactly daysBefore
+// days after the current date in the meatball day's time zone, if there is one.
+func reminderDue(
+	meatballDay models.MeatballDay,
+	daysBefore int,
+	guildSettings models.GuildSettings,
+	now time.Time,
+) (time.Time, bool) {
+	year, month, day := now.In(meatballDay.Location(guildSettings)).Date()
+	target := time.Date(
+		year,
+		month,
+		day+daysBefore,
+		0, 0, 0, 0,
+		meatballDay.Location(guildSettings),
+	)
+
+	date, ok := meatballDay.Occurrence(target.Year(), guildSettings)
+	if !ok || !date.Equal(target) {
+		return time.Time{}, false
+	}
+
+	return date, true
+}
+
+// Runs send unless the given reminder has already been sent. If send fails,
+// the reminder may be retried on a later check.
+func sendReminderOnce(
+	sentReminder models.SentReminder,
+	db *gorm.DB,
+	send func() error,
+) {
+	claimed, err := dal.ClaimReminder(sentReminder, db)
+	if err != nil {
+		log.Printf("Failed to check whether a reminder was sent: %v", err)
+		return
+	}
+
+	if !claimed {
+		return
+	}
+
+	if send() != nil {
+		if err := dal.ReleaseReminder(sentReminder, db); err != nil {
+			log.Printf("Failed to release reminder: %v", err)
+		}
+	}
+}
+
+func remindChannel(
+	p locale.Printer,
+	member *discordgo.Member,
+	date time.Time,
+	daysBefore int,
+	channelID string,
+	session *discordgo.Session,
+) error {
+	_, err := session.ChannelMessageSendComplex(
+		channelID,
+		&discordgo.MessageSend{
+			Content: p.Sprintf(
+				"Heads up! %v's meatball day is %v, on %v.",
+				member.Mention(),
+				daysUntil(p, daysBefore),
+				p.Date(date),
+			),
+			// don't spoil the surprise by pinging them.
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	)
+
+	if err != nil {
+		log.Printf(
+			"Failed to send reminder of %v's meatball day in %v: %v",
+			member.User.Username,
+			channelID,
+			err,
+		)
+	}
+
+	return err
+}
+
+func remindFollower(
+	p locale.Printer,
+	guild *discordgo.Guild,
+	member *discordgo.Member,
+	date time.Time,
+	daysBefore int,
+	followerID string,
+	session *discordgo.Session,
+) error {
+	channel, err := session.UserChannelCreate(followerID)
+	if err == nil {
+		_, err = session.ChannelMessageSend(
+			channel.ID,
+			p.Sprintf(
+				"Heads up! %v's meatball day in %v is %v, on %v.",
+				member.Mention(),
+				guild.Name,
+				daysUntil(p, daysBefore),
+				p.Date(date),
+			),
+		)
+	}
+
+	if err != nil {
+		log.Printf(
+			"Failed to DM reminder of %v's meatball day to %v: %v",
+			member.User.Username,
+			followerID,
+			err,
+		)
+	}
+
+	return err
+}
+
+// Describes a number of days in the future.
+func daysUntil(p locale.Printer, days int) string {
+	switch days {
+	case 0:
+		return p.Sprintf("today")
+	case 1:
+		return p.Sprintf("tomorrow")
+	default:
+		return p.Sprintf("in %v days", days)
+	}
+}
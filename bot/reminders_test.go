@@ -0,0 +1,107 @@
+package bot
+
+import (
+	"casper/models"
+	"testing"
+	"time"
+	_ "time/tzdata" // the tests don't depend on the host's zone database
+)
+
+func TestReminderDue(t *testing.T) {
+	leapDay := models.MeatballDay{Month: 2, Day: 29}
+
+	tests := []struct {
+		name        string
+		meatballDay models.MeatballDay
+		daysBefore  int
+		policy      string
+		now         time.Time
+		want        time.Time
+		wantOK      bool
+	}{
+		{
+			"a week before",
+			models.MeatballDay{Month: 6, Day: 15}, 7, "",
+			time.Date(2023, 6, 8, 12, 0, 0, 0, time.UTC),
+			time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC), true,
+		},
+		{
+			"too early",
+			models.MeatballDay{Month: 6, Day: 15}, 7, "",
+			time.Date(2023, 6, 7, 23, 59, 0, 0, time.UTC),
+			time.Time{}, false,
+		},
+		{
+			"across the new year",
+			models.MeatballDay{Month: 1, Day: 2}, 3, "",
+			time.Date(2022, 12, 30, 0, 0, 0, 0, time.UTC),
+			time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), true,
+		},
+		{
+			"leap day in a leap year",
+			leapDay, 1, models.LeapDaySkip,
+			time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), true,
+		},
+		{
+			"feb28 policy",
+			leapDay, 1, models.LeapDayFebruary28,
+			time.Date(2023, 2, 27, 0, 0, 0, 0, time.UTC),
+			time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC), true,
+		},
+		{
+			"mar1 policy",
+			leapDay, 1, models.LeapDayMarch1,
+			time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC),
+			time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), true,
+		},
+		{
+			"mar1 policy isn't due the day before 28th february",
+			leapDay, 1, models.LeapDayMarch1,
+			time.Date(2023, 2, 27, 0, 0, 0, 0, time.UTC),
+			time.Time{}, false,
+		},
+		{
+			"skip policy",
+			leapDay, 1, models.LeapDaySkip,
+			time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC),
+			time.Time{}, false,
+		},
+		{
+			"member's time zone is already a day ahead",
+			models.MeatballDay{Month: 6, Day: 15, TimeZone: "Pacific/Kiritimati"}, 7, "",
+			time.Date(2023, 6, 7, 12, 0, 0, 0, time.UTC),
+			time.Date(2023, 6, 15, 0, 0, 0, 0, mustLoadLocation(t, "Pacific/Kiritimati")), true,
+		},
+		{
+			"member's time zone is still a day behind",
+			models.MeatballDay{Month: 6, Day: 15, TimeZone: "Pacific/Pago_Pago"}, 7, "",
+			time.Date(2023, 6, 8, 5, 0, 0, 0, time.UTC),
+			time.Time{}, false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			guildSettings := models.GuildSettings{TimeZone: "UTC", LeapDayPolicy: test.policy}
+
+			date, ok := reminderDue(test.meatballDay, test.daysBefore, guildSettings, test.now)
+			if ok != test.wantOK || !date.Equal(test.want) {
+				t.Errorf(
+					"reminderDue(%v days before, %v) = %v, %v; want %v, %v",
+					test.daysBefore, test.now, date, ok, test.want, test.wantOK,
+				)
+			}
+		})
+	}
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := models.LoadTimeZone(name)
+	if err != nil {
+		t.Fatalf("LoadTimeZone(%q): %v", name, err)
+	}
+	return loc
+}
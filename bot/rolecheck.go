@@ -3,9 +3,11 @@ package bot
 import (
 	"casper/dal"
 	"casper/discordutils"
+	"casper/locale"
 	"casper/models"
-	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -15,55 +17,120 @@ import (
 // CheckRoles checks all joined guilds and updates their meatball roles.
 func CheckRoles(session *discordgo.Session, db *gorm.DB) {
 	for _, guild := range session.State.Guilds {
-		if role, ok := getRoleForGuild(guild, db); ok {
-			membersWithRole := discordutils.FindMembersWithRole(role, guild.Members)
+		CheckGuildRoles(guild, session, db)
+	}
+}
+
+// CheckGuildRoles updates the meatball roles in the given guild, and sends any
+// reminders or digests that are due.
+func CheckGuildRoles(
+	guild *discordgo.Guild,
+	session *discordgo.Session,
+	db *gorm.DB,
+) {
+	guildSettings, err := dal.GetGuildSettings(guild.ID, db)
+	if err != nil {
+		log.Printf("Failed to get settings for %v: %v", guild.Name, err)
+		return
+	}
+
+	p := guildPrinter(guild, *guildSettings)
+
+	sendReminders(p, guild, *guildSettings, time.Now(), session, db)
+	postDigest(p, guild, *guildSettings, time.Now(), session, db)
+
+	role, ok := getRoleForGuild(guild, db)
+	if !ok {
+		return
+	}
+
+	lastCheck, err := dal.GetLastRoleCheck(guild.ID, db)
+	if err != nil {
+		log.Printf("Failed to get last role check for %v: %v", guild.Name, err)
+		return
+	}
+
+	now := time.Now()
+
+	meatballMembers, meatballDays := getTodaysMeatballMembers(
+		guild,
+		guild.Members,
+		*guildSettings,
+		now,
+		db,
+	)
+
+	// before any belated announcements, so that their threads stay open until
+	// the next check.
+	archiveWishesThreads(guild, meatballDays, now, *guildSettings, session, db)
+
+	if !lastCheck.IsZero() && !guildSettings.SkipsMissedDays() {
+		announceMissedMeatballs(p, guild, lastCheck, now, *guildSettings, session, db)
+	}
 
-			userIDs := make([]string, len(membersWithRole))
-			for i, meatball := range membersWithRole {
-				userIDs[i] = meatball.User.ID
+	membersWithRole := discordutils.FindMembersWithRole(role, guild.Members)
+
+	userIDs := make([]string, len(membersWithRole))
+	for i, meatball := range membersWithRole {
+		userIDs[i] = meatball.User.ID
+	}
+
+	meatballDaysForUserIDs := getMeatballDaysForUserIDs(guild, userIDs, db)
+
+	expiredMeatballs := getExpiredMeatballs(
+		membersWithRole,
+		meatballDaysForUserIDs,
+		*guildSettings,
+	)
+	discordutils.RemoveRoleFromMembers(guild, role, expiredMeatballs, session)
+
+	if len(meatballMembers) > 0 {
+		var newMeatballs []*discordgo.Member
+		for _, member := range meatballMembers {
+			if !discordutils.MemberHasRole(member, role) {
+				newMeatballs = append(newMeatballs, member)
 			}
+		}
+		discordutils.AddRoleToMembers(guild, role, newMeatballs, session)
 
-			meatballDaysForUserIDs := getMeatballDaysForUserIDs(guild, userIDs, db)
-
-			expiredMeatballs := getExpiredMeatballs(membersWithRole, meatballDaysForUserIDs)
-			discordutils.RemoveRoleFromMembers(guild, role, expiredMeatballs, session)
-
-			meatballMembers := getTodaysMeatballMembers(guild, guild.Members, role, db)
-			if len(meatballMembers) > 0 {
-				discordutils.AddRoleToMembers(guild, role, meatballMembers, session)
-
-				meatballChannel, err := dal.GetMeatballChannel(guild.ID, db)
-				if err == nil {
-					for _, member := range meatballMembers {
-						announceMeatball(member, meatballChannel.ChannelID, session)
-					}
-				} else {
-					log.Printf(
-						"Can't announce new meatballs in %v: %v",
-						guild.Name,
-						err,
-					)
+		meatballChannel, err := dal.GetMeatballChannel(guild.ID, db)
+		if err == nil {
+			dates := make(map[string]time.Time)
+			for _, member := range meatballMembers {
+				meatballDay := meatballDays[member.User.ID]
+				year := now.In(meatballDay.Location(*guildSettings)).Year()
+				if date, ok := meatballDay.Occurrence(year, *guildSettings); ok {
+					dates[member.User.ID] = date
 				}
 			}
+
+			// members who already have the role are announced too, in case their
+			// announcement failed on an earlier check. the announcement record
+			// stops anyone being announced twice.
+			announceMeatballs(
+				p,
+				guild,
+				meatballMembers,
+				meatballDays,
+				dates,
+				false,
+				*guildSettings,
+				meatballChannel.ChannelID,
+				session,
+				db,
+			)
+		} else {
+			log.Printf(
+				"Can't announce new meatballs in %v: %v",
+				guild.Name,
+				err,
+			)
 		}
 	}
-}
 
-// RoleChecker runs CheckRoles on each tick of the given ticker.
-func RoleChecker(
-	session *discordgo.Session,
-	db *gorm.DB,
-	ticker *time.Ticker,
-	done chan bool,
-) {
-	for {
-		select {
-		case <-done:
-			log.Println("Stopped role checker.")
-			return
-		case <-ticker.C:
-			CheckRoles(session, db)
-		}
+	err = dal.UpsertRoleCheck(models.RoleCheck{GuildID: guild.ID, CheckedAt: now}, db)
+	if err != nil {
+		log.Printf("Failed to record role check for %v: %v", guild.Name, err)
 	}
 }
 
@@ -110,15 +177,15 @@ func getMeatballDaysForUserIDs(
 func getExpiredMeatballs(
 	meatballs []*discordgo.Member,
 	meatballDays map[string]models.MeatballDay,
+	guildSettings models.GuildSettings,
 ) []*discordgo.Member {
-	_, month, day := time.Now().Date()
+	now := time.Now()
 
 	var expired []*discordgo.Member
 
 	for _, meatball := range meatballs {
 		if meatballDay, ok := meatballDays[meatball.User.ID]; ok {
-			if int(meatballDay.Month) != int(month) ||
-				int(meatballDay.Day) != day {
+			if !meatballDay.IsOn(now, guildSettings) {
 				expired = append(expired, meatball)
 			}
 		} else {
@@ -130,14 +197,15 @@ func getExpiredMeatballs(
 	return expired
 }
 
+// Returns the members whose meatball day it is at the given instant, along with
+// the meatball days of everyone in the guild.
 func getTodaysMeatballMembers(
 	guild *discordgo.Guild,
 	members []*discordgo.Member,
-	role *discordgo.Role,
+	guildSettings models.GuildSettings,
+	now time.Time,
 	db *gorm.DB,
-) (meatballMembers []*discordgo.Member) {
-	_, month, day := time.Now().Date()
-
+) (meatballMembers []*discordgo.Member, memberToMeatballDay map[string]models.MeatballDay) {
 	var meatballDays []models.MeatballDay
 	err := db.Where(&models.MeatballDay{GuildID: guild.ID}).Find(&meatballDays).Error
 	if err != nil {
@@ -145,16 +213,14 @@ func getTodaysMeatballMembers(
 		return
 	}
 
-	memberToMeatballDay := make(map[string]models.MeatballDay)
+	memberToMeatballDay = make(map[string]models.MeatballDay)
 	for _, meatballDay := range meatballDays {
 		memberToMeatballDay[meatballDay.UserID] = meatballDay
 	}
 
 	for _, member := range members {
 		if meatballDay, ok := memberToMeatballDay[member.User.ID]; ok {
-			if int(meatballDay.Month) == int(month) &&
-				int(meatballDay.Day) == day &&
-				!discordutils.MemberHasRole(member, role) {
+			if meatballDay.IsOn(now, guildSettings) {
 				meatballMembers = append(meatballMembers, member)
 			}
 		}
@@ -163,25 +229,448 @@ func getTodaysMeatballMembers(
 	return
 }
 
+// Runs announce unless the given member's meatball day has already been
+// announced this year. If announce fails, the announcement may be retried on a
+// later check.
+func announceMeatballOnce(
+	guild *discordgo.Guild,
+	member *discordgo.Member,
+	year int,
+	db *gorm.DB,
+	announce func() error,
+) {
+	if !claimAnnouncement(guild, member, year, db) {
+		return
+	}
+
+	if announce() != nil {
+		releaseAnnouncement(guild, member, year, db)
+	}
+}
+
+// Records that the given member's meatball day is being announced this year.
+// Returns false if it has already been announced, or the record couldn't be
+// checked.
+func claimAnnouncement(
+	guild *discordgo.Guild,
+	member *discordgo.Member,
+	year int,
+	db *gorm.DB,
+) bool {
+	claimed, err := dal.ClaimAnnouncement(
+		models.Announcement{
+			GuildID: guild.ID,
+			UserID:  member.User.ID,
+			Year:    year,
+		},
+		db,
+	)
+
+	if err != nil {
+		log.Printf(
+			"Failed to check whether %v's meatball day was announced in %v: %v",
+			member.User.Username,
+			guild.Name,
+			err,
+		)
+		return false
+	}
+
+	return claimed
+}
+
+// Removes a claimed announcement from the record, so that it's retried on a
+// later check.
+func releaseAnnouncement(
+	guild *discordgo.Guild,
+	member *discordgo.Member,
+	year int,
+	db *gorm.DB,
+) {
+	err := dal.ReleaseAnnouncement(
+		models.Announcement{
+			GuildID: guild.ID,
+			UserID:  member.User.ID,
+			Year:    year,
+		},
+		db,
+	)
+
+	if err != nil {
+		log.Printf(
+			"Failed to release %v's announcement in %v: %v",
+			member.User.Username,
+			guild.Name,
+			err,
+		)
+	}
+}
+
+// Returns the guild's milestone template for the age the member turns on their
+// meatball day in the given year, if they show their age and there is one.
+func milestoneTemplate(
+	guild *discordgo.Guild,
+	meatballDay models.MeatballDay,
+	year int,
+	db *gorm.DB,
+) (string, bool) {
+	// ages can only be given away with the user's permission.
+	if meatballDay.Year == 0 || !meatballDay.ShowAge {
+		return "", false
+	}
+
+	age := meatballDay.AgeIn(year)
+	if age < 1 {
+		return "", false
+	}
+
+	milestoneTemplate, err := dal.GetMilestoneTemplate(guild.ID, uint(age), db)
+	if err != nil {
+		return "", false
+	}
+
+	return milestoneTemplate.Template, true
+}
+
+// Announces the given member's meatball day, using the guild's milestone
+// template for their age if it has one, or otherwise one of its announcement
+// templates at random. Belated announcements are for meatball days that were
+// missed while casper was offline.
 func announceMeatball(
+	p locale.Printer,
+	guild *discordgo.Guild,
 	member *discordgo.Member,
+	meatballDay models.MeatballDay,
+	year int,
+	belated bool,
+	guildSettings models.GuildSettings,
 	channelID string,
 	session *discordgo.Session,
-) {
-	_, err := session.ChannelMessageSend(
+	db *gorm.DB,
+) error {
+	members := []*discordgo.Member{member}
+
+	date := ""
+	if occurrence, ok := meatballDay.Occurrence(year, guildSettings); ok {
+		date = p.Date(occurrence)
+	}
+
+	values := newTemplateValues(p, guild, members, date)
+
+	if meatballDay.Year != 0 && meatballDay.ShowAge {
+		values.age = meatballDay.AgeIn(year)
+	}
+
+	content, ok := milestoneTemplate(guild, meatballDay, year, db)
+	if ok {
+		content = renderTemplate(content, values)
+		if belated {
+			content += belatedNote(p)
+		}
+	} else {
+		content = announcementContent(p, guild, false, belated, values, db)
+	}
+
+	return sendAnnouncement(
+		p,
+		guild,
+		content,
+		members,
+		values,
+		guildSettings,
 		channelID,
-		fmt.Sprintf(
-			"It's %v's meatball day! Congratulations.",
-			member.Mention(),
-		),
+		session,
+		db,
 	)
+}
+
+// Announces the meatball days of the given members, each for the date in dates.
+// Members reaching a milestone are always announced on their own. If the guild
+// combines announcements, the rest get a single message for each date they
+// fall on, as members in different time zones may be celebrating different
+// dates. Each member is only announced once a year.
+func announceMeatballs(
+	p locale.Printer,
+	guild *discordgo.Guild,
+	members []*discordgo.Member,
+	meatballDays map[string]models.MeatballDay,
+	dates map[string]time.Time,
+	belated bool,
+	guildSettings models.GuildSettings,
+	channelID string,
+	session *discordgo.Session,
+	db *gorm.DB,
+) {
+	var groupDates []string
+	groups := make(map[string][]*discordgo.Member)
+	occurrences := make(map[string]time.Time)
+
+	for _, member := range members {
+		meatballDay := meatballDays[member.User.ID]
+		occurrence, ok := dates[member.User.ID]
+		if !ok {
+			continue
+		}
+
+		if guildSettings.CombineAnnouncements {
+			_, hasMilestone := milestoneTemplate(guild, meatballDay, occurrence.Year(), db)
+			if !hasMilestone {
+				date := occurrence.Format(prettyDateFormat)
+				if _, ok := groups[date]; !ok {
+					groupDates = append(groupDates, date)
+					occurrences[date] = occurrence
+				}
+				groups[date] = append(groups[date], member)
+				continue
+			}
+		}
+
+		announceMeatballOnce(guild, member, occurrence.Year(), db, func() error {
+			return announceMeatball(
+				p,
+				guild,
+				member,
+				meatballDay,
+				occurrence.Year(),
+				belated,
+				guildSettings,
+				channelID,
+				session,
+				db,
+			)
+		})
+	}
+
+	for _, date := range groupDates {
+		announceMeatballGroup(
+			p,
+			guild,
+			groups[date],
+			meatballDays,
+			occurrences[date],
+			belated,
+			guildSettings,
+			channelID,
+			session,
+			db,
+		)
+	}
+}
+
+// Announces the meatball days of the given members, which all fall on the
+// given date, in a single message unless they have already been announced this
+// year. If only one of them hasn't, they get an announcement of their own.
+func announceMeatballGroup(
+	p locale.Printer,
+	guild *discordgo.Guild,
+	members []*discordgo.Member,
+	meatballDays map[string]models.MeatballDay,
+	date time.Time,
+	belated bool,
+	guildSettings models.GuildSettings,
+	channelID string,
+	session *discordgo.Session,
+	db *gorm.DB,
+) {
+	var claimed []*discordgo.Member
+	for _, member := range members {
+		if claimAnnouncement(guild, member, date.Year(), db) {
+			claimed = append(claimed, member)
+		}
+	}
+
+	var err error
+
+	switch len(claimed) {
+	case 0:
+		return
+	case 1:
+		member := claimed[0]
+		err = announceMeatball(
+			p,
+			guild,
+			member,
+			meatballDays[member.User.ID],
+			date.Year(),
+			belated,
+			guildSettings,
+			channelID,
+			session,
+			db,
+		)
+	default:
+		values := newTemplateValues(p, guild, claimed, p.Date(date))
+		content := announcementContent(p, guild, true, belated, values, db)
+		err = sendAnnouncement(
+			p,
+			guild,
+			content,
+			claimed,
+			values,
+			guildSettings,
+			channelID,
+			session,
+			db,
+		)
+	}
+
+	if err != nil {
+		for _, member := range claimed {
+			releaseAnnouncement(guild, member, date.Year(), db)
+		}
+	}
+}
 
+// Returns one of the guild's announcement templates at random filled in with
+// the given values, or the default announcement if it has none to pick from.
+// Combined and belated announcements have defaults of their own, and belated
+// ones note that they're late if they use a template.
+func announcementContent(
+	p locale.Printer,
+	guild *discordgo.Guild,
+	combined bool,
+	belated bool,
+	values templateValues,
+	db *gorm.DB,
+) string {
+	announcementTemplates, err := dal.GetAnnouncementTemplates(guild.ID, db)
 	if err != nil {
+		log.Printf("Failed to get announcement templates for %v: %v", guild.Name, err)
+	}
+
+	if template, ok := pickTemplate(announcementTemplates, combined, values); ok {
+		content := renderTemplate(template, values)
+		if belated {
+			content += belatedNote(p)
+		}
+		return content
+	}
+
+	switch {
+	case combined && belated:
+		return p.Sprintf(
+			"I missed the meatball day of %v on %v! Belated congratulations to all of you.",
+			values.mention,
+			values.date,
+		)
+	case combined:
+		return p.Sprintf(
+			"It's meatball day for %v! Congratulations to all of you.",
+			values.mention,
+		)
+	case belated:
+		return p.Sprintf(
+			"I missed %v's meatball day on %v! Belated congratulations.",
+			values.mention,
+			values.date,
+		)
+	default:
+		return p.Sprintf(
+			"It's %v's meatball day! Congratulations.",
+			values.mention,
+		)
+	}
+}
+
+// Returns the note added to templated announcements of meatball days that were
+// missed while casper was offline.
+func belatedNote(p locale.Printer) string {
+	return "\n" + p.Sprintf("(This one's belated, I missed it while I was offline.)")
+}
+
+// Posts an announcement of the given members' meatball day in the guild's
+// announcement style, and starts a thread on it for wishes if the guild wants
+// one.
+func sendAnnouncement(
+	p locale.Printer,
+	guild *discordgo.Guild,
+	content string,
+	members []*discordgo.Member,
+	values templateValues,
+	guildSettings models.GuildSettings,
+	channelID string,
+	session *discordgo.Session,
+	db *gorm.DB,
+) error {
+	messageSend := &discordgo.MessageSend{Content: content}
+	if guildSettings.EmbedsAnnouncements() {
+		messageSend = announcementEmbed(p, content, members, values, guildSettings)
+	}
+
+	message, err := session.ChannelMessageSendComplex(channelID, messageSend)
+
+	if err == nil {
+		startWishesThread(p, guild, message, members, values, guildSettings, session, db)
+	} else {
+		usernames := make([]string, len(members))
+		for i, member := range members {
+			usernames[i] = member.User.Username
+		}
+
 		log.Printf(
 			"Failed to announce %v's meatball day in %v: %v",
-			member.User.Username,
+			strings.Join(usernames, ", "),
 			channelID,
 			err,
 		)
 	}
+
+	return err
+}
+
+// Builds an embed announcement around the given content. The members are
+// mentioned outside of the embed, as mentions in embeds don't notify anyone.
+func announcementEmbed(
+	p locale.Printer,
+	content string,
+	members []*discordgo.Member,
+	values templateValues,
+	guildSettings models.GuildSettings,
+) *discordgo.MessageSend {
+	mentions := make([]string, len(members))
+	names := make([]string, len(members))
+	for i, member := range members {
+		mentions[i] = member.Mention()
+		names[i] = displayName(member)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       p.Sprintf("%v's meatball day", p.List(names)),
+		Description: content,
+		Color:       guildSettings.EmbedColor(),
+	}
+
+	// there's only room for one avatar.
+	if len(members) == 1 {
+		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{
+			URL: members[0].AvatarURL("256"),
+		}
+	}
+
+	if values.date != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   p.Sprintf("Date"),
+			Value:  values.date,
+			Inline: true,
+		})
+	}
+
+	if values.age > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   p.Sprintf("Age"),
+			Value:  strconv.Itoa(values.age),
+			Inline: true,
+		})
+	}
+
+	if guildSettings.AnnouncementImageURL != "" {
+		embed.Image = &discordgo.MessageEmbedImage{
+			URL: guildSettings.AnnouncementImageURL,
+		}
+	}
+
+	return &discordgo.MessageSend{
+		Content: strings.Join(mentions, " "),
+		Embeds:  []*discordgo.MessageEmbed{embed},
+	}
 }
@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"casper/dal"
+	"casper/models"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+)
+
+// Scheduler runs role checks at each local midnight that matters to a guild.
+// A guild's midnights come from its default time zone along with any time
+// zones its members have set on their meatball days.
+type Scheduler struct {
+	mu      sync.Mutex
+	planned map[string]time.Time // guild ID -> next role check
+	replan  chan struct{}
+}
+
+// NewScheduler creates a scheduler with nothing planned yet.
+func NewScheduler() *Scheduler {
+	return &Scheduler{replan: make(chan struct{}, 1)}
+}
+
+// Run checks the roles of each guild as its next midnight passes, until done
+// is signalled.
+func (scheduler *Scheduler) Run(
+	session *discordgo.Session,
+	db *gorm.DB,
+	done chan bool,
+) {
+	for {
+		wake, guildIDs, planned := planNextWake(session, db, time.Now())
+
+		scheduler.mu.Lock()
+		scheduler.planned = planned
+		scheduler.mu.Unlock()
+
+		log.Printf(
+			"Next role check at %v for %v guild(s).",
+			wake.Format(time.RFC3339),
+			len(guildIDs),
+		)
+
+		timer := time.NewTimer(time.Until(wake))
+
+		select {
+		case <-done:
+			timer.Stop()
+			log.Println("Stopped role checker.")
+			return
+		case <-scheduler.replan:
+			timer.Stop()
+		case <-timer.C:
+			for _, guildID := range guildIDs {
+				guild, err := session.State.Guild(guildID)
+				if err != nil {
+					log.Printf("Skipping role check for guild %v: %v", guildID, err)
+					continue
+				}
+				CheckGuildRoles(guild, session, db)
+			}
+		}
+	}
+}
+
+// Reschedule asks the scheduler to work out its next wake time again.
+// This should be called whenever a guild or user time zone changes.
+func (scheduler *Scheduler) Reschedule() {
+	select {
+	case scheduler.replan <- struct{}{}:
+	default:
+		// a replan is already pending
+	}
+}
+
+// NextWake returns the time the scheduler plans to next check the given
+// guild's roles. Returns false if it hasn't planned one yet.
+func (scheduler *Scheduler) NextWake(guildID string) (time.Time, bool) {
+	scheduler.mu.Lock()
+	defer scheduler.mu.Unlock()
+	wake, ok := scheduler.planned[guildID]
+	return wake, ok
+}
+
+// Finds the earliest upcoming midnight across all joined guilds, along with
+// every guild that has a midnight at that instant. Also returns each guild's
+// own next midnight.
+func planNextWake(
+	session *discordgo.Session,
+	db *gorm.DB,
+	now time.Time,
+) (wake time.Time, guildIDs []string, planned map[string]time.Time) {
+	planned = make(map[string]time.Time)
+
+	for _, guild := range session.State.Guilds {
+		guildWake, err := nextGuildMidnight(guild.ID, db, now)
+		if err != nil {
+			log.Printf("Failed to plan role check for %v: %v", guild.Name, err)
+			continue
+		}
+		planned[guild.ID] = guildWake
+
+		if len(guildIDs) == 0 || guildWake.Before(wake) {
+			wake = guildWake
+			guildIDs = []string{guild.ID}
+		} else if guildWake.Equal(wake) {
+			guildIDs = append(guildIDs, guild.ID)
+		}
+	}
+
+	if len(guildIDs) == 0 {
+		// nothing to do, but wake up anyway in case that changes.
+		wake = nextMidnight(now, time.Local)
+	}
+
+	return
+}
+
+// Finds the earliest upcoming midnight in any time zone used by the given guild.
+func nextGuildMidnight(
+	guildID string,
+	db *gorm.DB,
+	now time.Time,
+) (time.Time, error) {
+	guildSettings, err := dal.GetGuildSettings(guildID, db)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	timeZones, err := dal.GetMeatballDayTimeZones(guildID, db)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	wake := nextMidnight(now, guildSettings.Location())
+	for _, timeZone := range timeZones {
+		loc, err := models.LoadTimeZone(timeZone)
+		if err != nil {
+			continue
+		}
+
+		if midnight := nextMidnight(now, loc); midnight.Before(wake) {
+			wake = midnight
+		}
+	}
+
+	return wake, nil
+}
+
+// Returns the first midnight in the given location strictly after t.
+func nextMidnight(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+}
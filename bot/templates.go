@@ -0,0 +1,148 @@
+package bot
+
+import (
+	"casper/locale"
+	"casper/models"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxTemplateLength leaves room for the placeholders to be filled in without
+// going over discord's message length limit.
+const maxTemplateLength = 1000
+
+// templatePlaceholders are the placeholders that can be used in announcement
+// and milestone templates.
+var templatePlaceholders = []string{
+	"{mention}",
+	"{username}",
+	"{age}",
+	"{date}",
+	"{server}",
+}
+
+var placeholderPattern = regexp.MustCompile(`\{[^{}\s]*\}`)
+
+var errTemplateLength = fmt.Errorf(
+	"templates must be between 1 and %v characters",
+	maxTemplateLength,
+)
+
+// unknownPlaceholderError is returned for templates that use a placeholder
+// casper doesn't know.
+type unknownPlaceholderError struct {
+	placeholder string
+}
+
+func (err unknownPlaceholderError) Error() string {
+	return "unknown placeholder " + err.placeholder
+}
+
+// Checks that a template isn't too long and only uses known placeholders.
+func validateTemplate(template string) error {
+	if strings.TrimSpace(template) == "" || len(template) > maxTemplateLength {
+		return errTemplateLength
+	}
+
+	for _, placeholder := range placeholderPattern.FindAllString(template, -1) {
+		known := false
+		for _, templatePlaceholder := range templatePlaceholders {
+			if placeholder == templatePlaceholder {
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			return unknownPlaceholderError{placeholder}
+		}
+	}
+
+	return nil
+}
+
+// templateValues are the values filled in to a template's placeholders. For
+// combined announcements, mention and username list every member.
+type templateValues struct {
+	mention  string
+	username string
+	date     string
+	server   string
+	age      int // 0 if the member's age can't be shown
+}
+
+// Returns the values of a template announcing the given members' meatball day
+// on the given date.
+func newTemplateValues(
+	p locale.Printer,
+	guild *discordgo.Guild,
+	members []*discordgo.Member,
+	date string,
+) templateValues {
+	mentions := make([]string, len(members))
+	usernames := make([]string, len(members))
+	for i, member := range members {
+		mentions[i] = member.Mention()
+		usernames[i] = member.User.Username
+	}
+
+	return templateValues{
+		mention:  p.List(mentions),
+		username: p.List(usernames),
+		date:     date,
+		server:   guild.Name,
+	}
+}
+
+// Fills in the placeholders of a template.
+func renderTemplate(template string, values templateValues) string {
+	age := ""
+	if values.age > 0 {
+		age = strconv.Itoa(values.age)
+	}
+
+	return strings.NewReplacer(
+		"{mention}", values.mention,
+		"{username}", values.username,
+		"{age}", age,
+		"{date}", values.date,
+		"{server}", values.server,
+	).Replace(template)
+}
+
+// Picks one of the guild's announcement templates at random, leaving out any
+// that need an age if it can't be shown. Combined announcements only pick from
+// combined templates, and others only from the rest. Returns false if there are
+// none to pick from.
+func pickTemplate(
+	announcementTemplates []models.AnnouncementTemplate,
+	combined bool,
+	values templateValues,
+) (string, bool) {
+	var candidates []string
+	for _, announcementTemplate := range announcementTemplates {
+		if announcementTemplate.Combined != combined {
+			continue
+		}
+
+		if values.age > 0 || !usesAge(announcementTemplate.Template) {
+			candidates = append(candidates, announcementTemplate.Template)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	return candidates[rand.Intn(len(candidates))], true
+}
+
+// Returns true if the template has an {age} placeholder.
+func usesAge(template string) bool {
+	return strings.Contains(template, "{age}")
+}
@@ -0,0 +1,131 @@
+package bot
+
+import (
+	"casper/locale"
+	"casper/models"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const undoWindow = 5 * time.Minute
+
+// pendingUndo is a change to a user's meatball day that can still be undone by
+// restoring the meatball day as it was before.
+type pendingUndo struct {
+	previous models.MeatballDay
+	expires  time.Time
+}
+
+// undoStore holds the latest change to each user's meatball day until its undo
+// window closes.
+type undoStore struct {
+	mu    sync.Mutex
+	undos map[string]*pendingUndo
+}
+
+func newUndoStore() *undoStore {
+	return &undoStore{undos: make(map[string]*pendingUndo)}
+}
+
+// Stores the given meatball day to be restored on undo, and returns the ID to
+// undo with. Any earlier undo for the same user is dropped, since it would
+// restore a meatball day that has been changed since.
+func (store *undoStore) add(previous models.MeatballDay) (string, error) {
+	idBytes := make([]byte, 8)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(idBytes)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	now := time.Now()
+	for existingID, existing := range store.undos {
+		sameUser := existing.previous.GuildID == previous.GuildID &&
+			existing.previous.UserID == previous.UserID
+		if sameUser || now.After(existing.expires) {
+			delete(store.undos, existingID)
+		}
+	}
+
+	store.undos[id] = &pendingUndo{
+		previous: previous,
+		expires:  now.Add(undoWindow),
+	}
+
+	return id, nil
+}
+
+// Removes and returns the undo with the given ID, if it hasn't expired.
+func (store *undoStore) take(id string) (*pendingUndo, bool) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	pending, ok := store.undos[id]
+	if !ok {
+		return nil, false
+	}
+
+	delete(store.undos, id)
+	return pending, time.Now().Before(pending.expires)
+}
+
+// Drops any undo for the given user's meatball day in the given guild. Called
+// whenever their meatball day is saved, since an undo would otherwise restore
+// an older meatball day over the one just saved.
+func (store *undoStore) drop(guildID string, userID string) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for id, pending := range store.undos {
+		if pending.previous.GuildID == guildID && pending.previous.UserID == userID {
+			delete(store.undos, id)
+		}
+	}
+}
+
+// Drops every undo for the given user, in any guild, so that nothing can be
+// restored once their data has been erased.
+func (store *undoStore) dropUser(userID string) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for id, pending := range store.undos {
+		if pending.previous.UserID == userID {
+			delete(store.undos, id)
+		}
+	}
+}
+
+// Returns an undo button for the given meatball day change, or no components
+// if the change can't be undone.
+func (bot *Bot) undoButton(
+	p locale.Printer,
+	previous *models.MeatballDay,
+) []discordgo.MessageComponent {
+	if previous == nil {
+		return nil
+	}
+
+	id, err := bot.undos.add(*previous)
+	if err != nil {
+		return nil
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    p.Sprintf("Undo"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: makeCustomID("meatball-undo", id, previous.UserID),
+				},
+			},
+		},
+	}
+}
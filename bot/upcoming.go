@@ -0,0 +1,134 @@
+package bot
+
+import (
+	"casper/dal"
+	"casper/locale"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+)
+
+const upcomingPageSize = 10
+
+// Renders a page of upcoming meatball days, along with buttons to move
+// between pages. A count or days of 0 means no limit.
+func (bot *Bot) upcomingPage(
+	p locale.Printer,
+	guildID string,
+	count int,
+	days int,
+	page int,
+	db *gorm.DB,
+) (string, []discordgo.MessageComponent) {
+	guild, err := bot.session.State.Guild(guildID)
+	if err != nil {
+		return p.Sprintf("Failed to find this server: %v", err), nil
+	}
+
+	lines, err := upcomingMeatballLines(p, guild, count, days, time.Now(), db)
+	if err != nil {
+		return p.Sprintf("Failed to get upcoming meatball days: %v", err), nil
+	}
+
+	if len(lines) == 0 {
+		return p.Sprintf("There are no upcoming meatball days."), nil
+	}
+
+	pages := (len(lines) + upcomingPageSize - 1) / upcomingPageSize
+	if page < 0 {
+		page = 0
+	} else if page >= pages {
+		page = pages - 1
+	}
+
+	start := page * upcomingPageSize
+	end := start + upcomingPageSize
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	content := p.Sprintf(
+		"**Upcoming meatball days** (page %v of %v):\n%v",
+		page+1,
+		pages,
+		strings.Join(lines[start:end], "\n"),
+	)
+
+	if pages == 1 {
+		return content, nil
+	}
+
+	pageButton := func(label string, target int) discordgo.Button {
+		return discordgo.Button{
+			Label: label,
+			Style: discordgo.SecondaryButton,
+			CustomID: makeCustomID(
+				"meatball-upcoming",
+				strconv.Itoa(count),
+				strconv.Itoa(days),
+				strconv.Itoa(target),
+			),
+			Disabled: target < 0 || target >= pages,
+		}
+	}
+
+	return content, []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				pageButton(p.Sprintf("Previous"), page-1),
+				pageButton(p.Sprintf("Next"), page+1),
+			},
+		},
+	}
+}
+
+// Describes the guild members' meatball days in the order they next occur,
+// limited to the first count, and those within the given number of days.
+// A count or days of 0 means no limit.
+func upcomingMeatballLines(
+	p locale.Printer,
+	guild *discordgo.Guild,
+	count int,
+	days int,
+	now time.Time,
+	db *gorm.DB,
+) ([]string, error) {
+	upcoming, err := dal.GetUpcomingMeatballDays(guild.ID, now, db)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]*discordgo.Member)
+	for _, member := range guild.Members {
+		members[member.User.ID] = member
+	}
+
+	var lines []string
+	for _, meatballDay := range upcoming {
+		if count > 0 && len(lines) >= count {
+			break
+		}
+
+		daysAway := calendarDaysBetween(now, meatballDay.Date)
+		if days > 0 && daysAway >= days {
+			continue
+		}
+
+		member, ok := members[meatballDay.UserID]
+		if !ok {
+			continue
+		}
+
+		lines = append(lines, p.Sprintf(
+			"• %v: %v (%v)",
+			member.Mention(),
+			p.Date(meatballDay.Date),
+			daysUntil(p, daysAway),
+		))
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,177 @@
+package bot
+
+import (
+	"casper/dal"
+	"casper/locale"
+	"casper/models"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+)
+
+// maxThreadNameLength is discord's limit on the length of thread names.
+const maxThreadNameLength = 100
+
+// wishesAutoArchiveMinutes is long enough that a quiet thread stays open for
+// the whole meatball day, so that casper is the one to archive it.
+const wishesAutoArchiveMinutes = 4320
+
+var errThreadNameMention = errors.New("thread names can't use {mention}")
+
+// Checks that a wishes thread name is a valid template that discord can show.
+func validateThreadName(name string) error {
+	if err := validateTemplate(name); err != nil {
+		return err
+	}
+
+	// mentions are shown as raw IDs in thread names.
+	if strings.Contains(name, "{mention}") {
+		return errThreadNameMention
+	}
+
+	return nil
+}
+
+// Returns the name of the wishes thread for an announcement with the given
+// values.
+func wishesThreadName(
+	p locale.Printer,
+	values templateValues,
+	guildSettings models.GuildSettings,
+) string {
+	name := strings.TrimSpace(renderTemplate(guildSettings.WishesThreadName, values))
+
+	// a name like "{age}" renders to nothing if the age can't be shown.
+	if name == "" {
+		name = p.Sprintf("Wishes for %v", values.username)
+	}
+
+	if runes := []rune(name); len(runes) > maxThreadNameLength {
+		name = string(runes[:maxThreadNameLength])
+	}
+
+	return name
+}
+
+// Starts a thread on the given announcement for members to leave wishes in, if
+// the guild wants one.
+func startWishesThread(
+	p locale.Printer,
+	guild *discordgo.Guild,
+	message *discordgo.Message,
+	members []*discordgo.Member,
+	values templateValues,
+	guildSettings models.GuildSettings,
+	session *discordgo.Session,
+	db *gorm.DB,
+) {
+	if !guildSettings.WishesThreads {
+		return
+	}
+
+	thread, err := session.MessageThreadStartComplex(
+		message.ChannelID,
+		message.ID,
+		&discordgo.ThreadStart{
+			Name:                wishesThreadName(p, values, guildSettings),
+			AutoArchiveDuration: wishesAutoArchiveMinutes,
+		},
+	)
+
+	if err != nil {
+		log.Printf("Failed to start a wishes thread in %v: %v", guild.Name, err)
+		return
+	}
+
+	for _, member := range members {
+		err := dal.UpsertWishesThread(
+			models.WishesThread{
+				GuildID:  guild.ID,
+				UserID:   member.User.ID,
+				ThreadID: thread.ID,
+			},
+			db,
+		)
+
+		if err != nil {
+			log.Printf(
+				"Failed to record %v's wishes thread in %v: %v",
+				member.User.Username,
+				guild.Name,
+				err,
+			)
+		}
+	}
+}
+
+// Archives the wishes threads of members whose meatball day is over at the
+// given instant, as long as nobody else is still being wished well in them.
+// meatballDays holds the meatball days of everyone in the guild.
+func archiveWishesThreads(
+	guild *discordgo.Guild,
+	meatballDays map[string]models.MeatballDay,
+	now time.Time,
+	guildSettings models.GuildSettings,
+	session *discordgo.Session,
+	db *gorm.DB,
+) {
+	wishesThreads, err := dal.GetWishesThreads(guild.ID, db)
+	if err != nil {
+		log.Printf("Failed to get wishes threads in %v: %v", guild.Name, err)
+		return
+	}
+
+	for _, wishesThread := range wishesThreads {
+		meatballDay, ok := meatballDays[wishesThread.UserID]
+		if ok && meatballDay.IsOn(now, guildSettings) {
+			continue
+		}
+
+		_, err := dal.DeleteWishesThread(guild.ID, wishesThread.UserID, db)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		} else if err != nil {
+			log.Printf(
+				"Failed to remove %v's wishes thread in %v: %v",
+				wishesThread.UserID,
+				guild.Name,
+				err,
+			)
+			continue
+		}
+
+		if guildSettings.WishesPolicy == models.WishesKeep {
+			continue
+		}
+
+		remaining, err := dal.CountWishesThreadMembers(wishesThread.ThreadID, db)
+		if err != nil {
+			log.Printf("Failed to check wishes thread in %v: %v", guild.Name, err)
+			continue
+		}
+
+		if remaining > 0 {
+			continue
+		}
+
+		archived := true
+		edit := &discordgo.ChannelEdit{Archived: &archived}
+		if guildSettings.WishesPolicy == models.WishesLock {
+			edit.Locked = &archived
+		}
+
+		_, err = session.ChannelEditComplex(wishesThread.ThreadID, edit)
+		if err != nil {
+			log.Printf(
+				"Failed to archive %v's wishes thread in %v: %v",
+				wishesThread.UserID,
+				guild.Name,
+				err,
+			)
+		}
+	}
+}
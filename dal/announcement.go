@@ -0,0 +1,40 @@
+package dal
+
+import (
+	"casper/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ClaimAnnouncement records the given announcement.
+// Returns false if the announcement had already been recorded, in which case
+// it should not be made again.
+func ClaimAnnouncement(announcement models.Announcement, db *gorm.DB) (bool, error) {
+	result := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "guild_id"},
+			{Name: "user_id"},
+			{Name: "year"},
+		},
+		DoNothing: true,
+	}).Create(&announcement)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// ReleaseAnnouncement removes the given announcement from the record so that it
+// can be claimed again.
+func ReleaseAnnouncement(announcement models.Announcement, db *gorm.DB) error {
+	return db.Unscoped().Where(
+		&models.Announcement{
+			GuildID: announcement.GuildID,
+			UserID:  announcement.UserID,
+			Year:    announcement.Year,
+		},
+	).Delete(&models.Announcement{}).Error
+}
@@ -3,15 +3,14 @@ package dal
 import (
 	"casper/models"
 	"log"
-	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
-// InitDB creates and returns a database connection.
-func InitDB(dbPath string) *gorm.DB {
+// OpenDB returns a connection to the database as it is, without migrating it.
+func OpenDB(dbPath string) *gorm.DB {
 	db, err := gorm.Open(
 		sqlite.Open(dbPath),
 		&gorm.Config{
@@ -27,20 +26,137 @@ func InitDB(dbPath string) *gorm.DB {
 	}
 	log.Println("Connected to database.")
 
-	db.AutoMigrate(&models.MeatballDay{}, &models.MeatballRole{}, &models.MeatballChannel{})
+	return db
+}
+
+// InitDB creates and returns a database connection, migrating the database to
+// the current models.
+func InitDB(dbPath string) *gorm.DB {
+	db := OpenDB(dbPath)
+
+	db.AutoMigrate(
+		&models.MeatballDay{},
+		&models.MeatballRole{},
+		&models.MeatballChannel{},
+		&models.GuildSettings{},
+		&models.RoleCheck{},
+		&models.Announcement{},
+		&models.MilestoneTemplate{},
+		&models.AnnouncementTemplate{},
+		&models.WishesThread{},
+		&models.MeatballFollow{},
+		&models.SentReminder{},
+		&models.SentDigest{},
+		&models.FeedToken{},
+	)
 	log.Println("Migrated database.")
 
+	// meatball days used to be soft-deleted, which left them in the way of the
+	// unique index. they're hard-deleted now, so clear out any stragglers.
+	err := db.Unscoped().Where("deleted_at IS NOT NULL").Delete(&models.MeatballDay{}).Error
+	if err != nil {
+		log.Fatalf("Failed to purge forgotten meatball days: %v", err)
+	}
+
 	return db
 }
 
-// UpsertMeatballDay inserts or updates the given meatball day.
+// UpsertMeatballDay inserts or updates the given meatball day. An existing year
+// is kept if the given meatball day doesn't have one.
+// A soft-deleted meatball day in the way is restored, although none should
+// exist since DeleteMeatballDay deletes permanently.
 func UpsertMeatballDay(meatballDay models.MeatballDay, db *gorm.DB) error {
+	columns := []string{"month", "day", "deleted_at"}
+	if meatballDay.Year != 0 {
+		columns = append(columns, "year")
+	}
+
 	return db.Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "guild_id"}, {Name: "user_id"}},
-		DoUpdates: clause.AssignmentColumns([]string{"month", "day"}),
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).Create(&meatballDay).Error
+}
+
+// ReplaceMeatballDay inserts or updates the given meatball day, and returns the
+// meatball day it replaced. Returns nil if there wasn't one.
+func ReplaceMeatballDay(
+	meatballDay models.MeatballDay,
+	db *gorm.DB,
+) (*models.MeatballDay, error) {
+	var previous *models.MeatballDay
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var existing []models.MeatballDay
+		err := tx.Where(
+			&models.MeatballDay{
+				GuildID: meatballDay.GuildID,
+				UserID:  meatballDay.UserID,
+			},
+		).Limit(1).Find(&existing).Error
+		if err != nil {
+			return err
+		}
+
+		if len(existing) > 0 {
+			previous = &existing[0]
+		}
+
+		return UpsertMeatballDay(meatballDay, tx)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return previous, nil
+}
+
+// RestoreMeatballDay puts back a meatball day exactly as it was, whether it has
+// since been changed or deleted.
+func RestoreMeatballDay(meatballDay models.MeatballDay, db *gorm.DB) error {
+	// a deleted meatball day's ID may have been reused since, so let it get a
+	// new one.
+	meatballDay.ID = 0
+
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "guild_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns(
+			[]string{"month", "day", "year", "time_zone", "show_age", "private", "deleted_at"},
+		),
 	}).Create(&meatballDay).Error
 }
 
+// DeleteMeatballDay permanently deletes the meatball day for the given guild &
+// user, and returns what was deleted. Returns gorm.ErrRecordNotFound if there
+// wasn't one.
+func DeleteMeatballDay(
+	guildID string,
+	userID string,
+	db *gorm.DB,
+) (*models.MeatballDay, error) {
+	var meatballDay models.MeatballDay
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where(
+			&models.MeatballDay{
+				GuildID: guildID,
+				UserID:  userID,
+			},
+		).Take(&meatballDay).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Delete(&meatballDay).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &meatballDay, nil
+}
+
 // GetMeatballDay gets the meatball day for the given guild & user.
 func GetMeatballDay(
 	guildID string,
@@ -62,6 +178,93 @@ func GetMeatballDay(
 	return &meatballDay, nil
 }
 
+// SetMeatballDayTimeZone sets the time zone of the meatball day for the given
+// guild & user.
+func SetMeatballDayTimeZone(
+	guildID string,
+	userID string,
+	timeZone string,
+	db *gorm.DB,
+) error {
+	return updateMeatballDay(guildID, userID, "time_zone", timeZone, db)
+}
+
+// SetMeatballDayYear sets the birth year of the meatball day for the given
+// guild & user, or clears it if year is 0.
+func SetMeatballDayYear(
+	guildID string,
+	userID string,
+	year uint,
+	db *gorm.DB,
+) error {
+	return updateMeatballDay(guildID, userID, "year", year, db)
+}
+
+// SetMeatballDayShowAge sets whether the age of the user with the given
+// meatball day may be shown.
+func SetMeatballDayShowAge(
+	guildID string,
+	userID string,
+	showAge bool,
+	db *gorm.DB,
+) error {
+	return updateMeatballDay(guildID, userID, "show_age", showAge, db)
+}
+
+// SetMeatballDayPrivate sets whether the meatball day for the given guild &
+// user is left out of calendar exports.
+func SetMeatballDayPrivate(
+	guildID string,
+	userID string,
+	private bool,
+	db *gorm.DB,
+) error {
+	return updateMeatballDay(guildID, userID, "private", private, db)
+}
+
+// Updates a single column of the meatball day for the given guild & user.
+// Returns gorm.ErrRecordNotFound if there is no such meatball day.
+func updateMeatballDay(
+	guildID string,
+	userID string,
+	column string,
+	value interface{},
+	db *gorm.DB,
+) error {
+	result := db.Model(&models.MeatballDay{}).Where(
+		&models.MeatballDay{
+			GuildID: guildID,
+			UserID:  userID,
+		},
+	).Update(column, value)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetMeatballDayTimeZones returns every distinct time zone set on the meatball
+// days in the given guild.
+func GetMeatballDayTimeZones(guildID string, db *gorm.DB) ([]string, error) {
+	var timeZones []string
+	err := db.Model(&models.MeatballDay{}).Where(
+		"guild_id = ? AND time_zone <> ''",
+		guildID,
+	).Distinct("time_zone").Pluck("time_zone", &timeZones).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return timeZones, nil
+}
+
 // UpsertMeatballRole inserts or updates the given meatball role.
 func UpsertMeatballRole(meatballRole models.MeatballRole, db *gorm.DB) error {
 	return db.Clauses(clause.OnConflict{
@@ -99,60 +302,3 @@ func GetMeatballChannel(
 
 	return &meatballChannel, nil
 }
-
-// GetNextMeatballDay gets the next occurring meatball day.
-func GetNextMeatballDay(
-	guildID string,
-	db *gorm.DB,
-) (*models.MeatballDay, error) {
-	var meatballDays []models.MeatballDay
-	err := db.Where(
-		&models.MeatballDay{
-			GuildID: guildID,
-		},
-	).Order(
-		clause.OrderByColumn{
-			Column: clause.Column{
-				Name: "month",
-			},
-		},
-	).Order(
-		clause.OrderByColumn{
-			Column: clause.Column{
-				Name: "day",
-			},
-		},
-	).Find(&meatballDays).Error
-
-	if err != nil {
-		return nil, err
-	}
-
-	if len(meatballDays) == 0 {
-		return nil, nil
-	}
-
-	return findNextMeatballDay(meatballDays), nil
-}
-
-// Finds the next occurring meatball day.
-func findNextMeatballDay(meatballDays []models.MeatballDay) *models.MeatballDay {
-	now := time.Now()
-	var next *models.MeatballDay
-
-	for _, meatballDay := range meatballDays {
-		if time.Month(meatballDay.Month) > now.Month() {
-			next = &meatballDay
-			break
-		} else if time.Month(meatballDay.Month) == now.Month() && int(meatballDay.Day) > now.Day() {
-			next = &meatballDay
-			break
-		}
-	}
-
-	if next == nil {
-		next = &meatballDays[0]
-	}
-
-	return next
-}
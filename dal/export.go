@@ -0,0 +1,11 @@
+package dal
+
+import (
+	"gorm.io/gorm"
+)
+
+// GetGuildRecords finds every record of the given model that belongs to the
+// given guild. records must be a pointer to a slice of models with a GuildID.
+func GetGuildRecords(guildID string, records interface{}, db *gorm.DB) error {
+	return db.Where("guild_id = ?", guildID).Order("id").Find(records).Error
+}
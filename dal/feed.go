@@ -0,0 +1,52 @@
+package dal
+
+import (
+	"casper/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UpsertFeedToken inserts or replaces the given guild's feed token.
+func UpsertFeedToken(feedToken models.FeedToken, db *gorm.DB) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "guild_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash"}),
+	}).Create(&feedToken).Error
+}
+
+// DeleteFeedToken deletes the given guild's feed token.
+// Returns gorm.ErrRecordNotFound if the guild didn't have one.
+func DeleteFeedToken(guildID string, db *gorm.DB) error {
+	result := db.Unscoped().Where(
+		&models.FeedToken{
+			GuildID: guildID,
+		},
+	).Delete(&models.FeedToken{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetFeedToken finds the feed token with the given hash.
+func GetFeedToken(tokenHash string, db *gorm.DB) (*models.FeedToken, error) {
+	var feedToken models.FeedToken
+	err := db.Where(
+		&models.FeedToken{
+			TokenHash: tokenHash,
+		},
+	).Take(&feedToken).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &feedToken, nil
+}
@@ -0,0 +1,80 @@
+package dal
+
+import (
+	"casper/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UpsertMilestoneTemplate inserts or updates the given milestone template.
+func UpsertMilestoneTemplate(
+	milestoneTemplate models.MilestoneTemplate,
+	db *gorm.DB,
+) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "guild_id"}, {Name: "age"}},
+		DoUpdates: clause.AssignmentColumns([]string{"template"}),
+	}).Create(&milestoneTemplate).Error
+}
+
+// DeleteMilestoneTemplate deletes the milestone template for the given guild &
+// age. Returns gorm.ErrRecordNotFound if there wasn't one.
+func DeleteMilestoneTemplate(guildID string, age uint, db *gorm.DB) error {
+	// a struct condition would drop an age of 0 and match every template.
+	result := db.Unscoped().Where(
+		"guild_id = ? AND age = ?",
+		guildID,
+		age,
+	).Delete(&models.MilestoneTemplate{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetMilestoneTemplate gets the milestone template for the given guild & age.
+func GetMilestoneTemplate(
+	guildID string,
+	age uint,
+	db *gorm.DB,
+) (*models.MilestoneTemplate, error) {
+	var milestoneTemplate models.MilestoneTemplate
+	err := db.Where(
+		"guild_id = ? AND age = ?",
+		guildID,
+		age,
+	).Take(&milestoneTemplate).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &milestoneTemplate, nil
+}
+
+// GetMilestoneTemplates gets every milestone template for the given guild,
+// youngest first.
+func GetMilestoneTemplates(
+	guildID string,
+	db *gorm.DB,
+) ([]models.MilestoneTemplate, error) {
+	var milestoneTemplates []models.MilestoneTemplate
+	err := db.Where(
+		&models.MilestoneTemplate{
+			GuildID: guildID,
+		},
+	).Order("age").Find(&milestoneTemplates).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return milestoneTemplates, nil
+}
@@ -0,0 +1,99 @@
+package dal
+
+import (
+	"casper/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InsertMeatballFollow saves the given follow, if it isn't already saved.
+func InsertMeatballFollow(meatballFollow models.MeatballFollow, db *gorm.DB) error {
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "guild_id"},
+			{Name: "user_id"},
+			{Name: "followed_user_id"},
+		},
+		DoNothing: true,
+	}).Create(&meatballFollow).Error
+}
+
+// DeleteMeatballFollow deletes the given follow.
+// Returns gorm.ErrRecordNotFound if it wasn't saved.
+func DeleteMeatballFollow(meatballFollow models.MeatballFollow, db *gorm.DB) error {
+	result := db.Unscoped().Where(
+		&models.MeatballFollow{
+			GuildID:        meatballFollow.GuildID,
+			UserID:         meatballFollow.UserID,
+			FollowedUserID: meatballFollow.FollowedUserID,
+		},
+	).Delete(&models.MeatballFollow{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetMeatballFollowers returns the IDs of the users following the given user's
+// meatball day in the given guild.
+func GetMeatballFollowers(
+	guildID string,
+	followedUserID string,
+	db *gorm.DB,
+) ([]string, error) {
+	var userIDs []string
+	err := db.Model(&models.MeatballFollow{}).Where(
+		&models.MeatballFollow{
+			GuildID:        guildID,
+			FollowedUserID: followedUserID,
+		},
+	).Pluck("user_id", &userIDs).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+// ClaimReminder records the given reminder.
+// Returns false if the reminder had already been recorded, in which case it
+// should not be sent again.
+func ClaimReminder(sentReminder models.SentReminder, db *gorm.DB) (bool, error) {
+	result := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "guild_id"},
+			{Name: "user_id"},
+			{Name: "year"},
+			{Name: "days_before"},
+			{Name: "recipient_id"},
+		},
+		DoNothing: true,
+	}).Create(&sentReminder)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// ReleaseReminder removes the given reminder from the record so that it can be
+// claimed again.
+func ReleaseReminder(sentReminder models.SentReminder, db *gorm.DB) error {
+	return db.Unscoped().Where(
+		"guild_id = ? AND user_id = ? AND year = ? AND days_before = ? AND recipient_id = ?",
+		sentReminder.GuildID,
+		sentReminder.UserID,
+		sentReminder.Year,
+		sentReminder.DaysBefore,
+		sentReminder.RecipientID,
+	).Delete(&models.SentReminder{}).Error
+}
@@ -0,0 +1,95 @@
+package dal
+
+import (
+	"casper/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetGuildSettings returns the settings for the given guild.
+// If the guild has no settings saved, the defaults are returned.
+func GetGuildSettings(
+	guildID string,
+	db *gorm.DB,
+) (*models.GuildSettings, error) {
+	// Find rather than Take, a missing row is expected and not worth logging.
+	guildSettings := models.GuildSettings{GuildID: guildID}
+	err := db.Where(
+		&models.GuildSettings{
+			GuildID: guildID,
+		},
+	).Limit(1).Find(&guildSettings).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &guildSettings, nil
+}
+
+// UpsertGuildSettings inserts the given guild settings, or updates the given
+// columns if the guild already has settings saved.
+func UpsertGuildSettings(
+	guildSettings models.GuildSettings,
+	columns []string,
+	db *gorm.DB,
+) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "guild_id"}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).Create(&guildSettings).Error
+}
+
+// GetLastRoleCheck returns the time the given guild's roles were last checked.
+// The zero time is returned if the guild has never been checked.
+func GetLastRoleCheck(guildID string, db *gorm.DB) (time.Time, error) {
+	var roleCheck models.RoleCheck
+	err := db.Where(
+		&models.RoleCheck{
+			GuildID: guildID,
+		},
+	).Limit(1).Find(&roleCheck).Error
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return roleCheck.CheckedAt, nil
+}
+
+// UpsertRoleCheck inserts or updates the given role check.
+func UpsertRoleCheck(roleCheck models.RoleCheck, db *gorm.DB) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "guild_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"checked_at"}),
+	}).Create(&roleCheck).Error
+}
+
+// ClaimDigest records the given digest.
+// Returns false if the digest had already been recorded, in which case it
+// should not be posted again.
+func ClaimDigest(sentDigest models.SentDigest, db *gorm.DB) (bool, error) {
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "guild_id"}, {Name: "date"}},
+		DoNothing: true,
+	}).Create(&sentDigest)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// ReleaseDigest removes the given digest from the record so that it can be
+// claimed again.
+func ReleaseDigest(sentDigest models.SentDigest, db *gorm.DB) error {
+	return db.Unscoped().Where(
+		&models.SentDigest{
+			GuildID: sentDigest.GuildID,
+			Date:    sentDigest.Date,
+		},
+	).Delete(&models.SentDigest{}).Error
+}
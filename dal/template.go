@@ -0,0 +1,56 @@
+package dal
+
+import (
+	"casper/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateAnnouncementTemplate inserts the given announcement template, filling
+// in its ID.
+func CreateAnnouncementTemplate(
+	announcementTemplate *models.AnnouncementTemplate,
+	db *gorm.DB,
+) error {
+	return db.Create(announcementTemplate).Error
+}
+
+// DeleteAnnouncementTemplate deletes the guild's announcement template with the
+// given ID. Returns gorm.ErrRecordNotFound if there wasn't one.
+func DeleteAnnouncementTemplate(guildID string, id uint, db *gorm.DB) error {
+	result := db.Unscoped().Where(
+		"guild_id = ? AND id = ?",
+		guildID,
+		id,
+	).Delete(&models.AnnouncementTemplate{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetAnnouncementTemplates gets every announcement template for the given
+// guild, oldest first.
+func GetAnnouncementTemplates(
+	guildID string,
+	db *gorm.DB,
+) ([]models.AnnouncementTemplate, error) {
+	var announcementTemplates []models.AnnouncementTemplate
+	err := db.Where(
+		&models.AnnouncementTemplate{
+			GuildID: guildID,
+		},
+	).Order("id").Find(&announcementTemplates).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return announcementTemplates, nil
+}
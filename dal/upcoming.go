@@ -0,0 +1,100 @@
+package dal
+
+import (
+	"casper/models"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UpcomingMeatballDay is a meatball day along with the start of the day it is
+// next celebrated on.
+type UpcomingMeatballDay struct {
+	models.MeatballDay
+	Date time.Time
+}
+
+// GetUpcomingMeatballDays gets every meatball day in the given guild, ordered by
+// when they are next celebrated. Meatball days being celebrated at the given
+// instant count as upcoming.
+func GetUpcomingMeatballDays(
+	guildID string,
+	t time.Time,
+	db *gorm.DB,
+) ([]UpcomingMeatballDay, error) {
+	var meatballDays []models.MeatballDay
+	err := db.Where(
+		&models.MeatballDay{
+			GuildID: guildID,
+		},
+	).Order("month, day").Find(&meatballDays).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	guildSettings, err := GetGuildSettings(guildID, db)
+	if err != nil {
+		return nil, err
+	}
+
+	upcoming := make([]UpcomingMeatballDay, len(meatballDays))
+	for i, meatballDay := range meatballDays {
+		upcoming[i] = UpcomingMeatballDay{
+			MeatballDay: meatballDay,
+			Date:        meatballDay.UpcomingOccurrence(t, *guildSettings),
+		}
+	}
+
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].Date.Before(upcoming[j].Date)
+	})
+
+	return upcoming, nil
+}
+
+// GetNextMeatballDays gets the meatball days of the given users that are
+// celebrated on the earliest date any of them is. Meatball days being
+// celebrated at the given instant count as next.
+func GetNextMeatballDays(
+	guildID string,
+	userIDs []string,
+	t time.Time,
+	db *gorm.DB,
+) ([]UpcomingMeatballDay, error) {
+	upcoming, err := GetUpcomingMeatballDays(guildID, t, db)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[string]bool)
+	for _, userID := range userIDs {
+		users[userID] = true
+	}
+
+	// compare dates rather than instants, since a meatball day in a time zone
+	// further ahead can start sooner despite falling on a later date.
+	var next []UpcomingMeatballDay
+	for _, meatballDay := range upcoming {
+		if !users[meatballDay.UserID] {
+			continue
+		}
+
+		date := calendarDate(meatballDay.Date)
+		if len(next) == 0 || date.Before(calendarDate(next[0].Date)) {
+			next = []UpcomingMeatballDay{meatballDay}
+		} else if date.Equal(calendarDate(next[0].Date)) {
+			next = append(next, meatballDay)
+		}
+	}
+
+	return next, nil
+}
+
+// Returns the calendar date t falls on in its own time zone, as midnight UTC so
+// that dates from different time zones can be compared.
+func calendarDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,73 @@
+package dal
+
+import (
+	"casper/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// UserDataModel is a model that holds data about users, along with the columns
+// a user's ID may be stored in.
+type UserDataModel struct {
+	Model   interface{}
+	Columns []string
+
+	// records that only mention the user in these columns belong to someone
+	// else, e.g. their followers, so they're erased but never exported.
+	EraseColumns []string
+}
+
+// UserDataModels lists every model holding data about users.
+var UserDataModels = []UserDataModel{
+	{models.MeatballDay{}, []string{"user_id"}, nil},
+	{models.MeatballFollow{}, []string{"user_id"}, []string{"followed_user_id"}},
+	{models.Announcement{}, []string{"user_id"}, nil},
+	{models.SentReminder{}, []string{"recipient_id"}, []string{"user_id"}},
+	{models.WishesThread{}, []string{"user_id"}, nil},
+}
+
+// Restricts the query to records that mention the given user in any of the
+// given columns.
+func whereUser(userID string, columns []string, db *gorm.DB) *gorm.DB {
+	conditions := make([]string, len(columns))
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		conditions[i] = column + " = ?"
+		args[i] = userID
+	}
+	return db.Where(strings.Join(conditions, " OR "), args...)
+}
+
+// GetUserRecords finds every record of the given model that mentions the given
+// user, across all guilds, including soft-deleted records since those are still
+// stored. records must be a pointer to a slice of the model.
+func GetUserRecords(
+	userID string,
+	userDataModel UserDataModel,
+	records interface{},
+	db *gorm.DB,
+) error {
+	return whereUser(userID, userDataModel.Columns, db.Unscoped()).
+		Order("id").
+		Find(records).Error
+}
+
+// EraseUserData permanently deletes every record that mentions the given user,
+// across all guilds, including previously soft-deleted records.
+func EraseUserData(userID string, db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, userDataModel := range UserDataModels {
+			columns := append(
+				append([]string{}, userDataModel.Columns...),
+				userDataModel.EraseColumns...,
+			)
+			err := whereUser(userID, columns, tx.Unscoped()).
+				Delete(userDataModel.Model).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,78 @@
+package dal
+
+import (
+	"casper/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UpsertWishesThread inserts or updates the given wishes thread.
+func UpsertWishesThread(wishesThread models.WishesThread, db *gorm.DB) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "guild_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"thread_id"}),
+	}).Create(&wishesThread).Error
+}
+
+// GetWishesThreads gets every wishes thread recorded in the given guild.
+func GetWishesThreads(guildID string, db *gorm.DB) ([]models.WishesThread, error) {
+	var wishesThreads []models.WishesThread
+	err := db.Where(
+		&models.WishesThread{
+			GuildID: guildID,
+		},
+	).Find(&wishesThreads).Error
+
+	return wishesThreads, err
+}
+
+// DeleteWishesThread permanently deletes the wishes thread for the given guild &
+// user, and returns what was deleted. Returns gorm.ErrRecordNotFound if there
+// wasn't one.
+func DeleteWishesThread(
+	guildID string,
+	userID string,
+	db *gorm.DB,
+) (*models.WishesThread, error) {
+	var wishesThread models.WishesThread
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Find rather than Take, most members won't have a thread.
+		result := tx.Where(
+			&models.WishesThread{
+				GuildID: guildID,
+				UserID:  userID,
+			},
+		).Limit(1).Find(&wishesThread)
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Unscoped().Delete(&wishesThread).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &wishesThread, nil
+}
+
+// CountWishesThreadMembers counts the members still being wished well in the
+// given thread.
+func CountWishesThreadMembers(threadID string, db *gorm.DB) (int64, error) {
+	var count int64
+	err := db.Model(&models.WishesThread{}).Where(
+		&models.WishesThread{
+			ThreadID: threadID,
+		},
+	).Count(&count).Error
+
+	return count, err
+}
@@ -29,6 +29,15 @@ func RoleAllowsAdminPermissions(role *discordgo.Role) bool {
 	return role.Permissions&discordgo.PermissionAdministrator > 0
 }
 
+// InteractionUser returns the user who triggered the given interaction, whether
+// it came from a guild or a DM.
+func InteractionUser(interaction *discordgo.Interaction) *discordgo.User {
+	if interaction.Member != nil {
+		return interaction.Member.User
+	}
+	return interaction.User
+}
+
 // AckInteraction sends a deferred response for the given interaction.
 func AckInteraction(
 	interaction *discordgo.Interaction,
@@ -39,22 +48,87 @@ func AckInteraction(
 	})
 }
 
+// AckInteractionEphemeral sends a deferred response for the given interaction.
+// The followup will only be visible to the user who triggered the interaction.
+func AckInteractionEphemeral(
+	interaction *discordgo.Interaction,
+	session *discordgo.Session,
+) {
+	session.InteractionRespond(interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
 // SendFollowup creates a followup message with the given content.
 func SendFollowup(
 	content string,
 	interaction *discordgo.Interaction,
 	session *discordgo.Session,
 ) {
-	session.FollowupMessageCreate(
-		session.State.User.ID,
-		interaction,
-		true,
+	SendFollowupComplex(
 		&discordgo.WebhookParams{
 			Content: content,
 		},
+		interaction,
+		session,
 	)
 }
 
+// SendFollowupComplex creates a followup message with the given parameters.
+func SendFollowupComplex(
+	params *discordgo.WebhookParams,
+	interaction *discordgo.Interaction,
+	session *discordgo.Session,
+) {
+	_, err := session.FollowupMessageCreate(interaction, true, params)
+	if err != nil {
+		log.Printf("Failed to send followup to interaction %v: %v", interaction.ID, err)
+	}
+}
+
+// AckComponentInteraction sends a deferred response for the given message
+// component interaction. The message it's attached to can then be edited with
+// EditInteractionResponse.
+func AckComponentInteraction(
+	interaction *discordgo.Interaction,
+	session *discordgo.Session,
+) {
+	session.InteractionRespond(interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+}
+
+// EditInteractionResponse edits the original response to the given interaction.
+func EditInteractionResponse(
+	edit *discordgo.WebhookEdit,
+	interaction *discordgo.Interaction,
+	session *discordgo.Session,
+) {
+	_, err := session.InteractionResponseEdit(interaction, edit)
+	if err != nil {
+		log.Printf("Failed to edit response to interaction %v: %v", interaction.ID, err)
+	}
+}
+
+// UpdateComponentMessage responds to a message component interaction by
+// replacing the message the component is attached to.
+func UpdateComponentMessage(
+	data *discordgo.InteractionResponseData,
+	interaction *discordgo.Interaction,
+	session *discordgo.Session,
+) {
+	err := session.InteractionRespond(interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: data,
+	})
+	if err != nil {
+		log.Printf("Failed to update message for interaction %v: %v", interaction.ID, err)
+	}
+}
+
 // AddRoleToMembers adds the given role to all given members.
 func AddRoleToMembers(
 	guild *discordgo.Guild,
@@ -139,3 +213,16 @@ func FindMembersWithRole(
 	}
 	return
 }
+
+// GetOption finds the interaction option with the given name.
+func GetOption(
+	options []*discordgo.ApplicationCommandInteractionDataOption,
+	name string,
+) (*discordgo.ApplicationCommandInteractionDataOption, bool) {
+	for _, option := range options {
+		if option.Name == name {
+			return option, true
+		}
+	}
+	return nil, false
+}
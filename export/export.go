@@ -0,0 +1,215 @@
+// Package export writes a guild's casper data as JSON or CSV.
+package export
+
+import (
+	"casper/dal"
+	"casper/models"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Supported export formats.
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// naming gives tables and columns the same names they have in the database.
+var naming = schema.NamingStrategy{}
+
+// guildModels lists every model holding a guild's data, in export order.
+// Bookkeeping such as sent announcements and reminders is left out, as is the
+// calendar feed token since only its hash is stored.
+var guildModels = []interface{}{
+	models.GuildSettings{},
+	models.MeatballRole{},
+	models.MeatballChannel{},
+	models.MeatballDay{},
+	models.MilestoneTemplate{},
+	models.AnnouncementTemplate{},
+	models.MeatballFollow{},
+}
+
+// Table is a set of records of a single model.
+type Table struct {
+	Name    string
+	Columns []string
+	Rows    [][]interface{}
+}
+
+// Guild is all of the data casper stores about a guild.
+type Guild struct {
+	GuildID string
+	Tables  []Table
+}
+
+// User is all of the data casper stores about a user, across all guilds.
+type User struct {
+	UserID string
+	Tables []Table
+}
+
+// ValidFormat returns true if format is a supported export format.
+func ValidFormat(format string) bool {
+	return format == FormatJSON || format == FormatCSV
+}
+
+// Load reads all of the given guild's data from the database.
+func Load(guildID string, db *gorm.DB) (*Guild, error) {
+	guild := &Guild{GuildID: guildID}
+
+	for _, model := range guildModels {
+		modelType := reflect.TypeOf(model)
+		records := reflect.New(reflect.SliceOf(modelType))
+
+		err := dal.GetGuildRecords(guildID, records.Interface(), db)
+		if err != nil {
+			return nil, err
+		}
+
+		table := newTable(modelType, records.Elem(), "GuildID")
+		guild.Tables = append(guild.Tables, table)
+	}
+
+	return guild, nil
+}
+
+// LoadUser reads all of the given user's data from the database.
+func LoadUser(userID string, db *gorm.DB) (*User, error) {
+	user := &User{UserID: userID}
+
+	for _, userDataModel := range dal.UserDataModels {
+		modelType := reflect.TypeOf(userDataModel.Model)
+		records := reflect.New(reflect.SliceOf(modelType))
+
+		err := dal.GetUserRecords(userID, userDataModel, records.Interface(), db)
+		if err != nil {
+			return nil, err
+		}
+
+		user.Tables = append(user.Tables, newTable(modelType, records.Elem()))
+	}
+
+	return user, nil
+}
+
+// Builds a table from a slice of records. gorm's bookkeeping fields are left
+// out, since they mean nothing outside this database, as are any fields named
+// in omit.
+func newTable(modelType reflect.Type, records reflect.Value, omit ...string) Table {
+	table := Table{Name: naming.TableName(modelType.Name())}
+
+	omitted := make(map[string]bool)
+	for _, name := range omit {
+		omitted[name] = true
+	}
+
+	var fields []int
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		if field.Anonymous || field.PkgPath != "" || omitted[field.Name] {
+			continue
+		}
+
+		fields = append(fields, i)
+		table.Columns = append(table.Columns, naming.ColumnName("", field.Name))
+	}
+
+	for i := 0; i < records.Len(); i++ {
+		row := make([]interface{}, len(fields))
+		for j, field := range fields {
+			value := records.Index(i).Field(field)
+
+			// nullable columns are pointers, export what they point to.
+			if value.Kind() == reflect.Ptr {
+				if value.IsNil() {
+					continue
+				}
+				value = value.Elem()
+			}
+
+			row[j] = value.Interface()
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table
+}
+
+// Write writes the guild's data to w in the given format.
+func (guild Guild) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatJSON:
+		return guild.WriteJSON(w)
+	case FormatCSV:
+		return guild.WriteCSV(w)
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// WriteJSON writes the guild's data to w as a JSON object with an array of
+// records for each table.
+func (guild Guild) WriteJSON(w io.Writer) error {
+	return writeJSON(w, "guild_id", guild.GuildID, guild.Tables)
+}
+
+// WriteJSON writes the user's data to w as a JSON object with an array of
+// records for each table.
+func (user User) WriteJSON(w io.Writer) error {
+	return writeJSON(w, "user_id", user.UserID, user.Tables)
+}
+
+func writeJSON(w io.Writer, idKey string, id string, tables []Table) error {
+	object := map[string]interface{}{idKey: id}
+
+	for _, table := range tables {
+		records := make([]map[string]interface{}, len(table.Rows))
+		for i, row := range table.Rows {
+			records[i] = make(map[string]interface{})
+			for j, column := range table.Columns {
+				records[i][column] = row[j]
+			}
+		}
+		object[table.Name] = records
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(object)
+}
+
+// WriteCSV writes the guild's data to w as CSV. The first column of every row
+// names the table it belongs to, and each table starts with a header row whose
+// first column is the table's name prefixed with "#".
+func (guild Guild) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	writer.Write([]string{"#guild", "guild_id"})
+	writer.Write([]string{"guild", guild.GuildID})
+
+	for _, table := range guild.Tables {
+		writer.Write(append([]string{"#" + table.Name}, table.Columns...))
+
+		for _, row := range table.Rows {
+			record := []string{table.Name}
+			for _, value := range row {
+				if value == nil {
+					record = append(record, "")
+				} else {
+					record = append(record, fmt.Sprint(value))
+				}
+			}
+			writer.Write(record)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
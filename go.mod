@@ -3,7 +3,7 @@ module casper
 go 1.16
 
 require (
-	github.com/bwmarrin/discordgo v0.23.3-0.20210301043234-abe5ba6f0f66
+	github.com/bwmarrin/discordgo v0.27.1
 	github.com/dustin/go-humanize v1.0.0
 	github.com/mattn/go-sqlite3 v1.14.6 // indirect
 	gorm.io/driver/sqlite v1.1.4
@@ -0,0 +1,132 @@
+// Package ical writes iCalendar (RFC 5545) files of yearly all-day events.
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Rules for events on 29th February in non-leap years.
+const (
+	LeapDaySkip = iota
+	LeapDayFebruary28
+	LeapDayMarch1
+)
+
+// maxLineLength is the longest a content line may be before it must be folded.
+const maxLineLength = 75
+
+// dateFormat is the format of DATE values.
+const dateFormat = "20060102"
+
+// leapYear is the year all events start in. It is a leap year so that events
+// on 29th February are valid.
+const leapYear = 2000
+
+// Event is an all-day event that recurs every year.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Month       time.Month
+	Day         int
+	LeapDay     int // only used for events on 29th February
+}
+
+// Calendar is a named collection of events.
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Write writes the calendar to w in iCalendar format.
+func (calendar Calendar) Write(w io.Writer) error {
+	writer := &lineWriter{w: w}
+
+	writer.line("BEGIN:VCALENDAR")
+	writer.line("VERSION:2.0")
+	writer.line("PRODID:-//casper//meatball days//EN")
+	writer.line("CALSCALE:GREGORIAN")
+	writer.line("X-WR-CALNAME:" + escape(calendar.Name))
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	for _, event := range calendar.Events {
+		start := time.Date(leapYear, event.Month, event.Day, 0, 0, 0, 0, time.UTC)
+
+		writer.line("BEGIN:VEVENT")
+		writer.line("UID:" + escape(event.UID))
+		writer.line("DTSTAMP:" + stamp)
+		writer.line("DTSTART;VALUE=DATE:" + start.Format(dateFormat))
+		writer.line("DTEND;VALUE=DATE:" + start.AddDate(0, 0, 1).Format(dateFormat))
+		writer.line("RRULE:" + event.recurrence())
+		writer.line("SUMMARY:" + escape(event.Summary))
+		if event.Description != "" {
+			writer.line("DESCRIPTION:" + escape(event.Description))
+		}
+		writer.line("TRANSP:TRANSPARENT")
+		writer.line("END:VEVENT")
+	}
+
+	writer.line("END:VCALENDAR")
+
+	return writer.err
+}
+
+// Builds the recurrence rule for the event.
+func (event Event) recurrence() string {
+	if event.Month == time.February && event.Day == 29 {
+		switch event.LeapDay {
+		case LeapDayFebruary28:
+			// the last day of february, whichever that is.
+			return "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1"
+		case LeapDayMarch1:
+			// 29th february in leap years, 1st march otherwise.
+			return "FREQ=YEARLY;BYYEARDAY=60"
+		}
+	}
+
+	// yearly recurrences skip 29th february in non-leap years.
+	return "FREQ=YEARLY"
+}
+
+// Escapes special characters in a TEXT value.
+func escape(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// lineWriter writes CRLF terminated content lines, folding long ones.
+// The first error encountered is kept and later writes are skipped.
+type lineWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (writer *lineWriter) line(content string) {
+	if writer.err != nil {
+		return
+	}
+
+	var folded strings.Builder
+	length := 0
+	for _, r := range content {
+		size := len(string(r))
+		if length+size > maxLineLength {
+			folded.WriteString("\r\n ")
+			length = 1
+		}
+		folded.WriteRune(r)
+		length += size
+	}
+	folded.WriteString("\r\n")
+
+	_, writer.err = fmt.Fprint(writer.w, folded.String())
+}
@@ -0,0 +1,118 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestRecurrence(t *testing.T) {
+	tests := []struct {
+		name  string
+		event Event
+		want  string
+	}{
+		{"ordinary day", Event{Month: time.June, Day: 15}, "FREQ=YEARLY"},
+		{"leap day policy is ignored on other days", Event{Month: time.February, Day: 28, LeapDay: LeapDayMarch1}, "FREQ=YEARLY"},
+		{"leap day skipped", Event{Month: time.February, Day: 29, LeapDay: LeapDaySkip}, "FREQ=YEARLY"},
+		{"leap day on 28th february", Event{Month: time.February, Day: 29, LeapDay: LeapDayFebruary28}, "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1"},
+		{"leap day on 1st march", Event{Month: time.February, Day: 29, LeapDay: LeapDayMarch1}, "FREQ=YEARLY;BYYEARDAY=60"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.event.recurrence(); got != test.want {
+				t.Errorf("recurrence() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLineFolding(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"short", "SUMMARY:meatball day"},
+		{"exactly the limit", "SUMMARY:" + strings.Repeat("a", maxLineLength-len("SUMMARY:"))},
+		{"one over the limit", "SUMMARY:" + strings.Repeat("a", maxLineLength-len("SUMMARY:")+1)},
+		{"several folds", "DESCRIPTION:" + strings.Repeat("abcdefghij", 20)},
+		{"multibyte characters", "SUMMARY:" + strings.Repeat("🎂é", 40)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer := &lineWriter{w: &buf}
+			writer.line(test.content)
+			if writer.err != nil {
+				t.Fatalf("line() error = %v", writer.err)
+			}
+
+			output := buf.String()
+			if !strings.HasSuffix(output, "\r\n") {
+				t.Fatalf("output %q doesn't end with CRLF", output)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(output, "\r\n"), "\r\n")
+			for i, line := range lines {
+				if len(line) > maxLineLength {
+					t.Errorf("line %v is %v octets long, over %v", i, len(line), maxLineLength)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %v splits a character: %q", i, line)
+				}
+				if i > 0 && !strings.HasPrefix(line, " ") {
+					t.Errorf("continuation line %v doesn't start with a space: %q", i, line)
+				}
+			}
+
+			// unfolding gives back the original content.
+			if unfolded := strings.ReplaceAll(strings.TrimSuffix(output, "\r\n"), "\r\n ", ""); unfolded != test.content {
+				t.Errorf("unfolded content = %q, want %q", unfolded, test.content)
+			}
+
+			if len(test.content) <= maxLineLength && len(lines) != 1 {
+				t.Errorf("content of %v octets was folded into %v lines", len(test.content), len(lines))
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	calendar := Calendar{
+		Name: "Meatball days, in a server",
+		Events: []Event{
+			{
+				UID:         "1@casper",
+				Summary:     "alice's meatball day",
+				Description: "line one\nline two; with a comma, too",
+				Month:       time.February,
+				Day:         29,
+				LeapDay:     LeapDayMarch1,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := calendar.Write(&buf); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	output := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Meatball days\\, in a server\r\n",
+		"DTSTART;VALUE=DATE:20000229\r\n",
+		"DTEND;VALUE=DATE:20000301\r\n",
+		"RRULE:FREQ=YEARLY;BYYEARDAY=60\r\n",
+		"DESCRIPTION:line one\\nline two\\; with a comma\\, too\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Write() output is missing %q:\n%v", want, output)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package locale
+
+import "fmt"
+
+var german = &language{
+	name:     "Deutsch",
+	months:   [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+	weekdays: [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	date:     "%[2]v. %[1]v",
+	ordinal:  func(n int) string { return fmt.Sprintf("%v.", n) },
+	messages: map[string]string{
+		// general
+		"Nice try.":                       "Netter Versuch.",
+		"Save":                            "Speichern",
+		"Cancel":                          "Abbrechen",
+		"Undo":                            "Rückgängig",
+		"Import":                          "Importieren",
+		"Erase everything":                "Alles löschen",
+		"Previous":                        "Zurück",
+		"Next":                            "Weiter",
+		"%v and %v":                       "%v und %v",
+		"%v, %v":                          "%v %v",
+		" (%v)":                           " (%v)",
+		"\n**%v**: %v":                    "\n**%v**: %v",
+		"• %v: %v (%v)":                   "• %v: %v (%v)",
+		"Failed to find this server: %v":  "Ich konnte diesen Server nicht finden: %v",
+		"Failed to get meatball days: %v": "Ich konnte die Fleischbällchentage nicht abrufen: %v",
+		"Failed to get upcoming meatball days: %v": "Ich konnte die kommenden Fleischbällchentage nicht abrufen: %v",
+
+		// relative times
+		"today":       "heute",
+		"tomorrow":    "morgen",
+		"in %v days":  "in %v Tagen",
+		"now":         "jetzt",
+		"%v minute":   "%v Minute",
+		"%v minutes":  "%v Minuten",
+		"%v hour":     "%v Stunde",
+		"%v hours":    "%v Stunden",
+		"%v day":      "%v Tag",
+		"%v days":     "%v Tagen",
+		"%v from now": "in %v",
+		"%v ago":      "vor %v",
+
+		// looking up and saving meatball days
+		"%v hasn't registered their meatball day with me yet.": "%v hat mir den eigenen Fleischbällchentag noch nicht verraten.",
+		"I've got %v's meatball day down as %v.":               "Ich habe den Fleischbällchentag von %v am %v eingetragen.",
+		" They're %v years old.":                               " Das macht %v Jahre.",
+		"I don't understand %q as a date. Try %v format, for example %v, or write it out like 2 Jan or January 2nd.": "Ich verstehe %q nicht als Datum. Versuch das Format %v, zum Beispiel %v, oder schreib es aus, etwa 2. Jan oder 2. Januar.",
+		"The year %v doesn't match the year in %q.":                                   "Das Jahr %v passt nicht zum Jahr in %q.",
+		"%v isn't a valid year for a meatball day on %v.":                             "%v ist kein gültiges Jahr für einen Fleischbällchentag am %v.",
+		"I read %q as %v. Shall I save it?":                                           "Ich verstehe %q als %v. Soll ich das speichern?",
+		"Okay, I haven't saved anything.":                                             "Okay, ich habe nichts gespeichert.",
+		"Failed to set %v's meatball day: %v":                                         "Ich konnte den Fleischbällchentag von %v nicht speichern: %v",
+		"Saved %v as %v's meatball day.":                                              "Der %v ist jetzt als Fleischbällchentag von %v gespeichert.",
+		"You last changed your meatball day on %v at %v. You can change it again %v.": "Du hast deinen Fleischbällchentag zuletzt am %v um %v geändert. Du kannst ihn %v wieder ändern.",
+
+		// forgetting and undoing
+		"I don't seem to have your meatball day on record. Isn't that a lovely coincidence?":                          "Ich habe deinen Fleischbällchentag anscheinend gar nicht gespeichert. Was für ein schöner Zufall!",
+		"I'm unable to delete your meatball day from my database: %v\nPlease contact an admin to resolve this issue.": "Ich kann deinen Fleischbällchentag nicht aus meiner Datenbank löschen: %v\nBitte wende dich an einen Admin, um das zu klären.",
+		"I have erased your meatball day from my database.":                                                           "Ich habe deinen Fleischbällchentag aus meiner Datenbank gelöscht.",
+		"\nIt's too late to undo this.":                                                                               "\nDas lässt sich nicht mehr rückgängig machen.",
+		"\nFailed to undo: %v":                                                                                        "\nRückgängig machen fehlgeschlagen: %v",
+		"Put %v's meatball day back to %v.":                                                                           "Der Fleischbällchentag von %v ist wieder der %v.",
+
+		// personal data
+		"Failed to export your data: %v":                       "Ich konnte deine Daten nicht exportieren: %v",
+		"Here's everything I know about you, in every server.": "Hier ist alles, was ich über dich weiß, auf allen Servern.",
+		"This will permanently erase your meatball days, follows and reminder history in every server. It can't be undone. Are you sure?": "Damit werden deine Fleischbällchentage, wem du folgst und dein Erinnerungsverlauf auf allen Servern endgültig gelöscht. Das lässt sich nicht rückgängig machen. Bist du sicher?",
+		"Your data has been left alone.": "Deine Daten bleiben unangetastet.",
+		"I'm unable to erase your data: %v\nPlease contact an admin to resolve this issue.": "Ich kann deine Daten nicht löschen: %v\nBitte wende dich an einen Admin, um das zu klären.",
+		"I have erased everything I knew about you.":                                        "Ich habe alles gelöscht, was ich über dich wusste.",
+
+		// personal settings
+		"I don't know the time zone %v. Use a name from the IANA time zone database, such as Europe/London.": "Die Zeitzone %v kenne ich nicht. Nimm einen Namen aus der IANA-Zeitzonendatenbank, zum Beispiel Europe/Berlin.",
+		"You need to save your meatball day before you can set its time zone.":                               "Du musst deinen Fleischbällchentag speichern, bevor du seine Zeitzone festlegen kannst.",
+		"Failed to set %v's time zone: %v":                                                   "Ich konnte die Zeitzone von %v nicht ändern: %v",
+		"I will now celebrate %v's meatball day in %v.":                                      "Ich feiere den Fleischbällchentag von %v jetzt in %v.",
+		"You need to save your meatball day before you can choose whether to show your age.": "Du musst deinen Fleischbällchentag speichern, bevor du entscheiden kannst, ob dein Alter angezeigt wird.",
+		"Failed to update %v's age settings: %v":                                             "Ich konnte die Alterseinstellungen von %v nicht ändern: %v",
+		"I will show your age, as long as you've told me the year you were born.":            "Ich zeige dein Alter an, sofern du mir dein Geburtsjahr verraten hast.",
+		"I will keep your age to myself.":                                                    "Ich behalte dein Alter für mich.",
+		"You need to save your meatball day before you can hide it.":                         "Du musst deinen Fleischbällchentag speichern, bevor du ihn verbergen kannst.",
+		"Failed to update %v's privacy settings: %v":                                         "Ich konnte die Privatsphäre-Einstellungen von %v nicht ändern: %v",
+		"I will leave your meatball day out of calendar exports.":                            "Ich lasse deinen Fleischbällchentag aus Kalenderexporten heraus.",
+		"I will include your meatball day in calendar exports.":                              "Ich nehme deinen Fleischbällchentag in Kalenderexporte auf.",
+
+		// following
+		"I'm sure you'll remember your own meatball day.": "Deinen eigenen Fleischbällchentag vergisst du bestimmt nicht.",
+		"Failed to follow %v: %v":                         "Ich konnte %v nicht folgen: %v",
+		"I will DM you reminders before %v's meatball day, as long as this server has reminders enabled.": "Ich schicke dir vor dem Fleischbällchentag von %v eine Erinnerung per DM, sofern dieser Server Erinnerungen aktiviert hat.",
+		"You weren't following %v.":                                   "Du bist %v nicht gefolgt.",
+		"Failed to unfollow %v: %v":                                   "Ich konnte %v nicht entfolgen: %v",
+		"I will no longer DM you reminders before %v's meatball day.": "Ich schicke dir vor dem Fleischbällchentag von %v keine Erinnerungen mehr.",
+
+		// server settings
+		"That role allows admin permissions, that's a bad idea.":                            "Diese Rolle hat Admin-Rechte, das ist keine gute Idee.",
+		"Failed to set new role: %v":                                                        "Ich konnte die neue Rolle nicht festlegen: %v",
+		"I will now assign %v on meatball day.":                                             "Ich vergebe jetzt %v am Fleischbällchentag.",
+		"Failed to set new channel: %v":                                                     "Ich konnte den neuen Kanal nicht festlegen: %v",
+		"I will now use %v for announcements.":                                              "Ich verwende jetzt %v für Ankündigungen.",
+		"Failed to set time zone: %v":                                                       "Ich konnte die Zeitzone nicht festlegen: %v",
+		"I will now use %v for anyone who hasn't set their own time zone.":                  "Ich verwende jetzt %v für alle, die keine eigene Zeitzone festgelegt haben.",
+		"Failed to set missed day policy: %v":                                               "Ich konnte nicht festlegen, was mit verpassten Tagen passiert: %v",
+		"I will no longer announce meatball days I missed while offline.":                   "Ich kündige Fleischbällchentage, die ich offline verpasst habe, nicht mehr an.",
+		"I will announce meatball days I missed while offline as soon as I'm back.":         "Ich kündige Fleischbällchentage, die ich offline verpasst habe, an, sobald ich zurück bin.",
+		"Failed to set leap day policy: %v":                                                 "Ich konnte nicht festlegen, was mit dem 29. Februar passiert: %v",
+		"In non-leap years, I will celebrate 29th February meatball days on 1st March.":     "In Nicht-Schaltjahren feiere ich Fleischbällchentage vom 29. Februar am 1. März.",
+		"In non-leap years, I will skip 29th February meatball days.":                       "In Nicht-Schaltjahren lasse ich Fleischbällchentage vom 29. Februar aus.",
+		"In non-leap years, I will celebrate 29th February meatball days on 28th February.": "In Nicht-Schaltjahren feiere ich Fleischbällchentage vom 29. Februar am 28. Februar.",
+		"Failed to set date order: %v":                                                      "Ich konnte die Datumsreihenfolge nicht festlegen: %v",
+		"I will read dates like 2/1 as 2nd January.":                                        "Ich lese Daten wie 2/1 als 2. Januar.",
+		"I will read dates like 2/1 as 1st February.":                                       "Ich lese Daten wie 2/1 als 1. Februar.",
+		"Failed to set language: %v":                                                        "Ich konnte die Sprache nicht festlegen: %v",
+		"I will speak this server's Discord language.":                                      "Ich spreche die Discord-Sprache dieses Servers.",
+		"I will speak %v in this server.":                                                   "Ich spreche auf diesem Server %v.",
+
+		// milestones
+		"Milestone ages have to be at least 1.":               "Meilenstein-Alter müssen mindestens 1 sein.",
+		"Failed to set milestone announcement: %v":            "Ich konnte die Meilenstein-Ankündigung nicht festlegen: %v",
+		"I will announce everyone's %v meatball day with: %v": "Ich kündige den %v Fleischbällchentag aller an mit: %v",
+		"There's no milestone announcement for age %v.":       "Es gibt keine Meilenstein-Ankündigung für das Alter %v.",
+		"Failed to remove milestone announcement: %v":         "Ich konnte die Meilenstein-Ankündigung nicht entfernen: %v",
+		"Removed the milestone announcement for age %v.":      "Die Meilenstein-Ankündigung für das Alter %v ist entfernt.",
+		"Failed to get milestone announcements: %v":           "Ich konnte die Meilenstein-Ankündigungen nicht abrufen: %v",
+		"There are no milestone announcements set up yet.":    "Es gibt noch keine Meilenstein-Ankündigungen.",
+		"Milestone announcements:":                            "Meilenstein-Ankündigungen:",
+
+		// announcement templates
+		"Failed to add announcement: %v":                                       "Ich konnte die Ankündigung nicht hinzufügen: %v",
+		"Added announcement %v: %v":                                            "Ankündigung %v hinzugefügt: %v",
+		"There's no announcement %v.":                                          "Es gibt keine Ankündigung %v.",
+		"Failed to remove announcement: %v":                                    "Ich konnte die Ankündigung nicht entfernen: %v",
+		"Removed announcement %v.":                                             "Ankündigung %v entfernt.",
+		"Failed to get announcements: %v":                                      "Ich konnte die Ankündigungen nicht abrufen: %v",
+		"There are no announcements set up yet, so I'm using the default one.": "Es gibt noch keine Ankündigungen, also verwende ich die Standardankündigung.",
+		"Announcements, one of which is picked at random:":                     "Ankündigungen, von denen ich zufällig eine auswähle:",
+		"I don't know the placeholder %v. You can use %v.":                     "Den Platzhalter %v kenne ich nicht. Du kannst %v verwenden.",
+		"Announcements have to be between 1 and %v characters long.":           "Ankündigungen müssen zwischen 1 und %v Zeichen lang sein.",
+		"%q isn't a color. Use a hex code like #e67e22.":                       "%q ist keine Farbe. Verwende einen Hex-Code wie #e67e22.",
+		"%q isn't a link to an image. Use an http or https link.":              "%q ist kein Link zu einem Bild. Verwende einen http- oder https-Link.",
+		"Failed to set announcement style: %v":                                 "Ich konnte den Stil der Ankündigungen nicht festlegen: %v",
+		"I will announce meatball days in an embed.":                           "Ich kündige Fleischbällchentage in einem Embed an.",
+		"I will announce meatball days in a plain message.":                    "Ich kündige Fleischbällchentage in einer einfachen Nachricht an.",
+		"Date": "Datum",
+		"Age":  "Alter",
+		"Combined announcements can't use {age}, as everyone's age is different.":                           "Kombinierte Ankündigungen können {age} nicht verwenden, weil jeder ein anderes Alter hat.",
+		"Added combined announcement %v: %v":                                                                "Kombinierte Ankündigung %v hinzugefügt: %v",
+		"\n**%v** (combined): %v":                                                                           "\n**%v** (kombiniert): %v",
+		"Failed to set combined announcements: %v":                                                          "Ich konnte die kombinierten Ankündigungen nicht festlegen: %v",
+		"I will announce everyone who shares a meatball day in one message, unless they reach a milestone.": "Ich kündige alle, die sich einen Fleischbällchentag teilen, in einer Nachricht an, außer sie erreichen einen Meilenstein.",
+		"I will announce everyone's meatball day in a message of its own.":                                  "Ich kündige jeden Fleischbällchentag in einer eigenen Nachricht an.",
+		"Wishes for %v": "Glückwünsche für %v",
+		"Thread names can't use {mention}, as mentions don't work in them.":                                             "Thread-Namen können {mention} nicht verwenden, weil Erwähnungen darin nicht funktionieren.",
+		"Failed to set up wishes threads: %v":                                                                           "Ich konnte die Glückwunsch-Threads nicht einrichten: %v",
+		"I will no longer start threads for wishes.":                                                                    "Ich starte keine Glückwunsch-Threads mehr.",
+		"I will start a thread for wishes on each announcement, and archive it when the meatball day is over.":          "Ich starte zu jeder Ankündigung einen Glückwunsch-Thread und archiviere ihn, wenn der Fleischbällchentag vorbei ist.",
+		"I will start a thread for wishes on each announcement, and archive and lock it when the meatball day is over.": "Ich starte zu jeder Ankündigung einen Glückwunsch-Thread und archiviere und sperre ihn, wenn der Fleischbällchentag vorbei ist.",
+		"I will start a thread for wishes on each announcement, and leave it open until it goes quiet.":                 "Ich starte zu jeder Ankündigung einen Glückwunsch-Thread und lasse ihn offen, bis es darin ruhig wird.",
+		"\nThere are too many to fit in a message, so they're in the attached file.":                                    "\nDas sind zu viele für eine Nachricht, deshalb stehen sie in der angehängten Datei.",
+
+		// reminders and digests
+		"%q isn't a number of days between 1 and %v. Give me a comma separated list of days, such as 7,1, or \"off\".": "%q ist keine Anzahl von Tagen zwischen 1 und %v. Gib mir eine kommagetrennte Liste von Tagen, etwa 7,1, oder \"off\".",
+		"Failed to set reminders: %v":      "Ich konnte die Erinnerungen nicht festlegen: %v",
+		"I will no longer send reminders.": "Ich verschicke keine Erinnerungen mehr.",
+		"I will send reminders %v days before each meatball day in the announcement channel.": "Ich verschicke Erinnerungen %v Tage vor jedem Fleischbällchentag im Ankündigungskanal.",
+		"I will send reminders %v days before each meatball day in <#%v>.":                    "Ich verschicke Erinnerungen %v Tage vor jedem Fleischbällchentag in <#%v>.",
+		"Digests can cover between 1 and %v days.":                                            "Übersichten können zwischen 1 und %v Tagen abdecken.",
+		"Failed to set digest schedule: %v":                                                   "Ich konnte den Zeitplan der Übersicht nicht festlegen: %v",
+		"I will post a digest of upcoming meatball days every %v.":                            "Ich poste jeden %v eine Übersicht der kommenden Fleischbällchentage.",
+		"I will post a digest of upcoming meatball days on the 1st of every month.":           "Ich poste am 1. jedes Monats eine Übersicht der kommenden Fleischbällchentage.",
+		"I will no longer post digests of upcoming meatball days.":                            "Ich poste keine Übersichten der kommenden Fleischbällchentage mehr.",
+		"**Meatball days in the next %v days:**\n%v":                                          "**Fleischbällchentage in den nächsten %v Tagen:**\n%v",
+		"Heads up! %v's meatball day is %v, on %v.":                                           "Achtung! Der Fleischbällchentag von %v ist %v, am %v.",
+		"Heads up! %v's meatball day in %v is %v, on %v.":                                     "Achtung! Der Fleischbällchentag von %v auf %v ist %v, am %v.",
+
+		// calendars and feeds
+		"Calendar feeds aren't enabled on this casper.": "Kalender-Feeds sind bei diesem casper nicht aktiviert.",
+		"Failed to generate feed link: %v":              "Ich konnte keinen Feed-Link erzeugen: %v",
+		"Here's the new feed link, subscribe to it in your calendar app: %v\nAnyone with the link can see the meatball days, so keep it secret. I won't be able to show it to you again.": "Hier ist der neue Feed-Link, abonniere ihn in deiner Kalender-App: %v\nJeder mit dem Link kann die Fleischbällchentage sehen, also halte ihn geheim. Ich kann ihn dir nicht noch einmal zeigen.",
+		"There's no feed link to revoke.":    "Es gibt keinen Feed-Link zum Widerrufen.",
+		"Failed to revoke feed link: %v":     "Ich konnte den Feed-Link nicht widerrufen: %v",
+		"The feed link will no longer work.": "Der Feed-Link funktioniert nicht mehr.",
+		"Failed to export meatball days: %v": "Ich konnte die Fleischbällchentage nicht exportieren: %v",
+		"Here are this server's meatball days. Import the file into your calendar app to never miss one.": "Hier sind die Fleischbällchentage dieses Servers. Importiere die Datei in deine Kalender-App, damit du keinen verpasst.",
+		"Meatball days in %v %v": "Fleischbällchentage im %v %v",
+		"Meatball days in %v":    "Fleischbällchentage auf %v",
+		"%v's meatball day":      "Fleischbällchentag von %v",
+		"%v more":                "%v weitere",
+		"\n…and %v more day.":    "\n…und %v weiterer Tag.",
+		"\n…and %v more days.":   "\n…und %v weitere Tage.",
+
+		// importing and exporting
+		"I couldn't read %v: %v": "Ich konnte %v nicht lesen: %v",
+		"%v has %v rows I can import and %v with problems. Check the attached preview before confirming.": "%v hat %v Zeilen, die ich importieren kann, und %v mit Problemen. Prüf die angehängte Vorschau, bevor du bestätigst.",
+		"Failed to prepare import: %v":                                                            "Ich konnte den Import nicht vorbereiten: %v",
+		"This import has expired. Run the command again to start over.":                           "Dieser Import ist abgelaufen. Führe den Befehl erneut aus, um neu anzufangen.",
+		"Cancelled the import of %v.":                                                             "Der Import von %v ist abgebrochen.",
+		"Imported %v meatball days from %v, %v rows failed. The attached report has the details.": "%v Fleischbällchentage aus %v importiert, %v Zeilen sind fehlgeschlagen. Der angehängte Bericht enthält die Details.",
+		"Failed to export server data: %v":                                                        "Ich konnte die Serverdaten nicht exportieren: %v",
+		"Here's everything I know about this server.":                                             "Hier ist alles, was ich über diesen Server weiß.",
+		"the file is bigger than %v bytes":                                                        "die Datei ist größer als %v Bytes",
+		"downloading the file failed: %v":                                                         "die Datei konnte nicht heruntergeladen werden: %v",
+		"only .csv and .json files can be imported":                                               "nur .csv- und .json-Dateien können importiert werden",
+		"expected at least a user and a date":                                                     "mindestens ein Benutzer und ein Datum erwartet",
+		"%q isn't a date in %v or YYYY-MM-DD format":                                              "%q ist kein Datum im Format %v oder YYYY-MM-DD",
+		"%q isn't a year":                                                                         "%q ist kein Jahr",
+		"%v isn't a valid year for a meatball day on %v":                                          "%v ist kein gültiges Jahr für einen Fleischbällchentag am %v",
+		"%q matches more than one member, use their ID instead":                                   "%q passt zu mehr als einem Mitglied, verwende stattdessen die ID",
+		"%q isn't a member of this server":                                                        "%q ist kein Mitglied dieses Servers",
+		"%v already appears on line %v":                                                           "%v steht schon in Zeile %v",
+		"line %v: failed: %v":                                                                     "Zeile %v: fehlgeschlagen: %v",
+		"line %v: will import %v (%v) as %v":                                                      "Zeile %v: %v (%v) wird mit dem %v importiert",
+		"line %v: imported %v (%v) as %v":                                                         "Zeile %v: %v (%v) mit dem %v importiert",
+
+		// upcoming meatball days
+		"I haven't planned the next role check here yet. Try again in a moment.": "Ich habe die nächste Rollenprüfung hier noch nicht geplant. Versuch es gleich noch einmal.",
+		"I'll next check meatball roles here at %v, %v.":                         "Ich prüfe die Fleischbällchen-Rollen hier das nächste Mal am %v, %v.",
+		"Failed to get next meatball day: %v":                                    "Ich konnte den nächsten Fleischbällchentag nicht abrufen: %v",
+		"There are no meatball days registered yet.":                             "Es sind noch keine Fleischbällchentage eingetragen.",
+		"Today is %v's meatball day!":                                            "Heute ist der Fleischbällchentag von %v!",
+		"The next meatball day is %v's on %v, %v.":                               "Der nächste Fleischbällchentag ist der von %v am %v, %v.",
+		"I can't list a negative number of meatball days.":                       "Ich kann keine negative Anzahl von Fleischbällchentagen auflisten.",
+		"There are no upcoming meatball days.":                                   "Es gibt keine kommenden Fleischbällchentage.",
+		"**Upcoming meatball days** (page %v of %v):\n%v":                        "**Kommende Fleischbällchentage** (Seite %v von %v):\n%v",
+
+		// announcements
+		"It's %v's meatball day! Congratulations.":                                      "%v hat heute Fleischbällchentag! Herzlichen Glückwunsch.",
+		"It's meatball day for %v! Congratulations to all of you.":                      "%v haben heute Fleischbällchentag! Herzlichen Glückwunsch euch allen.",
+		"I missed the meatball day of %v on %v! Belated congratulations to all of you.": "Ich habe den Fleischbällchentag von %v am %v verpasst! Nachträglich herzlichen Glückwunsch euch allen.",
+		"(This one's belated, I missed it while I was offline.)":                        "(Nachträglich, ich habe ihn verpasst, während ich offline war.)",
+		"I missed %v's meatball day on %v! Belated congratulations.":                    "Ich habe den Fleischbällchentag von %v am %v verpasst! Nachträglich herzlichen Glückwunsch.",
+	},
+}
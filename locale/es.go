@@ -0,0 +1,233 @@
+package locale
+
+import "fmt"
+
+var spanish = &language{
+	name:     "Español",
+	months:   [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+	weekdays: [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	date:     "%[2]v de %[1]v",
+	ordinal:  func(n int) string { return fmt.Sprintf("%vº", n) },
+	messages: map[string]string{
+		// general
+		"Nice try.":                       "Buen intento.",
+		"Save":                            "Guardar",
+		"Cancel":                          "Cancelar",
+		"Undo":                            "Deshacer",
+		"Import":                          "Importar",
+		"Erase everything":                "Borrarlo todo",
+		"Previous":                        "Anterior",
+		"Next":                            "Siguiente",
+		"%v and %v":                       "%v y %v",
+		"%v, %v":                          "%v de %v",
+		" (%v)":                           " (%v)",
+		"\n**%v**: %v":                    "\n**%v**: %v",
+		"• %v: %v (%v)":                   "• %v: %v (%v)",
+		"Failed to find this server: %v":  "No he podido encontrar este servidor: %v",
+		"Failed to get meatball days: %v": "No he podido obtener los días de albóndiga: %v",
+		"Failed to get upcoming meatball days: %v": "No he podido obtener los próximos días de albóndiga: %v",
+
+		// relative times
+		"today":       "hoy",
+		"tomorrow":    "mañana",
+		"in %v days":  "dentro de %v días",
+		"now":         "ahora",
+		"%v minute":   "%v minuto",
+		"%v minutes":  "%v minutos",
+		"%v hour":     "%v hora",
+		"%v hours":    "%v horas",
+		"%v day":      "%v día",
+		"%v days":     "%v días",
+		"%v from now": "dentro de %v",
+		"%v ago":      "hace %v",
+
+		// looking up and saving meatball days
+		"%v hasn't registered their meatball day with me yet.": "%v todavía no me ha dicho cuál es su día de albóndiga.",
+		"I've got %v's meatball day down as %v.":               "Tengo apuntado el día de albóndiga de %v el %v.",
+		" They're %v years old.":                               " Tiene %v años.",
+		"I don't understand %q as a date. Try %v format, for example %v, or write it out like 2 Jan or January 2nd.": "No entiendo %q como fecha. Usa el formato %v, por ejemplo %v, o escríbela como 2 ene o 2 de enero.",
+		"The year %v doesn't match the year in %q.":                                   "El año %v no coincide con el año de %q.",
+		"%v isn't a valid year for a meatball day on %v.":                             "%v no es un año válido para un día de albóndiga el %v.",
+		"I read %q as %v. Shall I save it?":                                           "He entendido %q como %v. ¿Lo guardo?",
+		"Okay, I haven't saved anything.":                                             "Vale, no he guardado nada.",
+		"Failed to set %v's meatball day: %v":                                         "No he podido guardar el día de albóndiga de %v: %v",
+		"Saved %v as %v's meatball day.":                                              "He guardado el %v como el día de albóndiga de %v.",
+		"You last changed your meatball day on %v at %v. You can change it again %v.": "Cambiaste tu día de albóndiga por última vez el %v a las %v. Podrás volver a cambiarlo %v.",
+
+		// forgetting and undoing
+		"I don't seem to have your meatball day on record. Isn't that a lovely coincidence?":                          "No parece que tenga apuntado tu día de albóndiga. ¡Qué casualidad tan bonita!",
+		"I'm unable to delete your meatball day from my database: %v\nPlease contact an admin to resolve this issue.": "No puedo borrar tu día de albóndiga de mi base de datos: %v\nPide a un administrador que lo resuelva.",
+		"I have erased your meatball day from my database.":                                                           "He borrado tu día de albóndiga de mi base de datos.",
+		"\nIt's too late to undo this.":                                                                               "\nYa es demasiado tarde para deshacer esto.",
+		"\nFailed to undo: %v":                                                                                        "\nNo he podido deshacerlo: %v",
+		"Put %v's meatball day back to %v.":                                                                           "He vuelto a poner el día de albóndiga de %v el %v.",
+
+		// personal data
+		"Failed to export your data: %v":                       "No he podido exportar tus datos: %v",
+		"Here's everything I know about you, in every server.": "Aquí tienes todo lo que sé de ti, en todos los servidores.",
+		"This will permanently erase your meatball days, follows and reminder history in every server. It can't be undone. Are you sure?": "Esto borrará para siempre tus días de albóndiga, a quién sigues y tu historial de recordatorios en todos los servidores. No se puede deshacer. ¿Seguro?",
+		"Your data has been left alone.": "No he tocado tus datos.",
+		"I'm unable to erase your data: %v\nPlease contact an admin to resolve this issue.": "No puedo borrar tus datos: %v\nPide a un administrador que lo resuelva.",
+		"I have erased everything I knew about you.":                                        "He borrado todo lo que sabía de ti.",
+
+		// personal settings
+		"I don't know the time zone %v. Use a name from the IANA time zone database, such as Europe/London.": "No conozco la zona horaria %v. Usa un nombre de la base de datos de zonas horarias de IANA, como Europe/Madrid.",
+		"You need to save your meatball day before you can set its time zone.":                               "Tienes que guardar tu día de albóndiga antes de elegir su zona horaria.",
+		"Failed to set %v's time zone: %v":                                                   "No he podido cambiar la zona horaria de %v: %v",
+		"I will now celebrate %v's meatball day in %v.":                                      "A partir de ahora celebraré el día de albóndiga de %v en %v.",
+		"You need to save your meatball day before you can choose whether to show your age.": "Tienes que guardar tu día de albóndiga antes de elegir si se muestra tu edad.",
+		"Failed to update %v's age settings: %v":                                             "No he podido cambiar los ajustes de edad de %v: %v",
+		"I will show your age, as long as you've told me the year you were born.":            "Mostraré tu edad, siempre que me hayas dicho en qué año naciste.",
+		"I will keep your age to myself.":                                                    "Me guardaré tu edad para mí.",
+		"You need to save your meatball day before you can hide it.":                         "Tienes que guardar tu día de albóndiga antes de poder ocultarlo.",
+		"Failed to update %v's privacy settings: %v":                                         "No he podido cambiar los ajustes de privacidad de %v: %v",
+		"I will leave your meatball day out of calendar exports.":                            "Dejaré tu día de albóndiga fuera de los calendarios exportados.",
+		"I will include your meatball day in calendar exports.":                              "Incluiré tu día de albóndiga en los calendarios exportados.",
+
+		// following
+		"I'm sure you'll remember your own meatball day.": "Seguro que te acuerdas de tu propio día de albóndiga.",
+		"Failed to follow %v: %v":                         "No he podido seguir a %v: %v",
+		"I will DM you reminders before %v's meatball day, as long as this server has reminders enabled.": "Te enviaré recordatorios por mensaje directo antes del día de albóndiga de %v, siempre que este servidor tenga los recordatorios activados.",
+		"You weren't following %v.":                                   "No estabas siguiendo a %v.",
+		"Failed to unfollow %v: %v":                                   "No he podido dejar de seguir a %v: %v",
+		"I will no longer DM you reminders before %v's meatball day.": "Ya no te enviaré recordatorios antes del día de albóndiga de %v.",
+
+		// server settings
+		"That role allows admin permissions, that's a bad idea.":                            "Ese rol tiene permisos de administrador, no es buena idea.",
+		"Failed to set new role: %v":                                                        "No he podido cambiar el rol: %v",
+		"I will now assign %v on meatball day.":                                             "A partir de ahora asignaré %v el día de albóndiga.",
+		"Failed to set new channel: %v":                                                     "No he podido cambiar el canal: %v",
+		"I will now use %v for announcements.":                                              "A partir de ahora usaré %v para los anuncios.",
+		"Failed to set time zone: %v":                                                       "No he podido cambiar la zona horaria: %v",
+		"I will now use %v for anyone who hasn't set their own time zone.":                  "A partir de ahora usaré %v para quien no haya elegido su propia zona horaria.",
+		"Failed to set missed day policy: %v":                                               "No he podido cambiar qué hacer con los días perdidos: %v",
+		"I will no longer announce meatball days I missed while offline.":                   "Ya no anunciaré los días de albóndiga que me perdí mientras estaba desconectado.",
+		"I will announce meatball days I missed while offline as soon as I'm back.":         "Anunciaré los días de albóndiga que me perdí mientras estaba desconectado en cuanto vuelva.",
+		"Failed to set leap day policy: %v":                                                 "No he podido cambiar qué hacer con el 29 de febrero: %v",
+		"In non-leap years, I will celebrate 29th February meatball days on 1st March.":     "En los años no bisiestos, celebraré los días de albóndiga del 29 de febrero el 1 de marzo.",
+		"In non-leap years, I will skip 29th February meatball days.":                       "En los años no bisiestos, me saltaré los días de albóndiga del 29 de febrero.",
+		"In non-leap years, I will celebrate 29th February meatball days on 28th February.": "En los años no bisiestos, celebraré los días de albóndiga del 29 de febrero el 28 de febrero.",
+		"Failed to set date order: %v":                                                      "No he podido cambiar el orden de las fechas: %v",
+		"I will read dates like 2/1 as 2nd January.":                                        "Leeré fechas como 2/1 como el 2 de enero.",
+		"I will read dates like 2/1 as 1st February.":                                       "Leeré fechas como 2/1 como el 1 de febrero.",
+		"Failed to set language: %v":                                                        "No he podido cambiar el idioma: %v",
+		"I will speak this server's Discord language.":                                      "Hablaré el idioma de Discord de este servidor.",
+		"I will speak %v in this server.":                                                   "Hablaré %v en este servidor.",
+
+		// milestones
+		"Milestone ages have to be at least 1.":               "Las edades especiales tienen que ser como mínimo 1.",
+		"Failed to set milestone announcement: %v":            "No he podido guardar el anuncio especial: %v",
+		"I will announce everyone's %v meatball day with: %v": "Anunciaré el %v día de albóndiga de todo el mundo con: %v",
+		"There's no milestone announcement for age %v.":       "No hay ningún anuncio especial para la edad %v.",
+		"Failed to remove milestone announcement: %v":         "No he podido quitar el anuncio especial: %v",
+		"Removed the milestone announcement for age %v.":      "He quitado el anuncio especial para la edad %v.",
+		"Failed to get milestone announcements: %v":           "No he podido obtener los anuncios especiales: %v",
+		"There are no milestone announcements set up yet.":    "Todavía no hay anuncios especiales.",
+		"Milestone announcements:":                            "Anuncios especiales:",
+
+		// announcement templates
+		"Failed to add announcement: %v":                                       "No he podido añadir el anuncio: %v",
+		"Added announcement %v: %v":                                            "He añadido el anuncio %v: %v",
+		"There's no announcement %v.":                                          "No hay ningún anuncio %v.",
+		"Failed to remove announcement: %v":                                    "No he podido quitar el anuncio: %v",
+		"Removed announcement %v.":                                             "He quitado el anuncio %v.",
+		"Failed to get announcements: %v":                                      "No he podido obtener los anuncios: %v",
+		"There are no announcements set up yet, so I'm using the default one.": "Todavía no hay anuncios, así que uso el de siempre.",
+		"Announcements, one of which is picked at random:":                     "Anuncios, de los que elijo uno al azar:",
+		"I don't know the placeholder %v. You can use %v.":                     "No conozco el marcador %v. Puedes usar %v.",
+		"Announcements have to be between 1 and %v characters long.":           "Los anuncios tienen que tener entre 1 y %v caracteres.",
+		"%q isn't a color. Use a hex code like #e67e22.":                       "%q no es un color. Usa un código hexadecimal como #e67e22.",
+		"%q isn't a link to an image. Use an http or https link.":              "%q no es un enlace a una imagen. Usa un enlace http o https.",
+		"Failed to set announcement style: %v":                                 "No he podido cambiar el estilo de los anuncios: %v",
+		"I will announce meatball days in an embed.":                           "Anunciaré los días de albóndiga en un mensaje enriquecido.",
+		"I will announce meatball days in a plain message.":                    "Anunciaré los días de albóndiga en un mensaje normal.",
+		"Date": "Fecha",
+		"Age":  "Edad",
+		"Combined announcements can't use {age}, as everyone's age is different.":                           "Los anuncios combinados no pueden usar {age}, porque cada uno tiene una edad distinta.",
+		"Added combined announcement %v: %v":                                                                "He añadido el anuncio combinado %v: %v",
+		"\n**%v** (combined): %v":                                                                           "\n**%v** (combinado): %v",
+		"Failed to set combined announcements: %v":                                                          "No he podido cambiar los anuncios combinados: %v",
+		"I will announce everyone who shares a meatball day in one message, unless they reach a milestone.": "Anunciaré en un solo mensaje a todos los que compartan día de albóndiga, salvo si cumplen una edad especial.",
+		"I will announce everyone's meatball day in a message of its own.":                                  "Anunciaré el día de albóndiga de cada uno en su propio mensaje.",
+		"Wishes for %v": "Felicitaciones para %v",
+		"Thread names can't use {mention}, as mentions don't work in them.":                                             "Los nombres de los hilos no pueden usar {mention}, porque las menciones no funcionan en ellos.",
+		"Failed to set up wishes threads: %v":                                                                           "No he podido configurar los hilos de felicitaciones: %v",
+		"I will no longer start threads for wishes.":                                                                    "Ya no abriré hilos de felicitaciones.",
+		"I will start a thread for wishes on each announcement, and archive it when the meatball day is over.":          "Abriré un hilo de felicitaciones en cada anuncio, y lo archivaré cuando termine el día de albóndiga.",
+		"I will start a thread for wishes on each announcement, and archive and lock it when the meatball day is over.": "Abriré un hilo de felicitaciones en cada anuncio, y lo archivaré y bloquearé cuando termine el día de albóndiga.",
+		"I will start a thread for wishes on each announcement, and leave it open until it goes quiet.":                 "Abriré un hilo de felicitaciones en cada anuncio, y lo dejaré abierto hasta que deje de tener actividad.",
+		"\nThere are too many to fit in a message, so they're in the attached file.":                                    "\nSon demasiados para un mensaje, así que están en el archivo adjunto.",
+
+		// reminders and digests
+		"%q isn't a number of days between 1 and %v. Give me a comma separated list of days, such as 7,1, or \"off\".": "%q no es un número de días entre 1 y %v. Dame una lista de días separados por comas, como 7,1, u \"off\".",
+		"Failed to set reminders: %v":      "No he podido cambiar los recordatorios: %v",
+		"I will no longer send reminders.": "Ya no enviaré recordatorios.",
+		"I will send reminders %v days before each meatball day in the announcement channel.": "Enviaré recordatorios %v días antes de cada día de albóndiga en el canal de anuncios.",
+		"I will send reminders %v days before each meatball day in <#%v>.":                    "Enviaré recordatorios %v días antes de cada día de albóndiga en <#%v>.",
+		"Digests can cover between 1 and %v days.":                                            "Los resúmenes pueden cubrir entre 1 y %v días.",
+		"Failed to set digest schedule: %v":                                                   "No he podido cambiar el calendario de resúmenes: %v",
+		"I will post a digest of upcoming meatball days every %v.":                            "Publicaré un resumen de los próximos días de albóndiga cada %v.",
+		"I will post a digest of upcoming meatball days on the 1st of every month.":           "Publicaré un resumen de los próximos días de albóndiga el día 1 de cada mes.",
+		"I will no longer post digests of upcoming meatball days.":                            "Ya no publicaré resúmenes de los próximos días de albóndiga.",
+		"**Meatball days in the next %v days:**\n%v":                                          "**Días de albóndiga en los próximos %v días:**\n%v",
+		"Heads up! %v's meatball day is %v, on %v.":                                           "¡Atención! El día de albóndiga de %v es %v, el %v.",
+		"Heads up! %v's meatball day in %v is %v, on %v.":                                     "¡Atención! El día de albóndiga de %v en %v es %v, el %v.",
+
+		// calendars and feeds
+		"Calendar feeds aren't enabled on this casper.": "Los calendarios en directo no están activados en este casper.",
+		"Failed to generate feed link: %v":              "No he podido generar el enlace del calendario: %v",
+		"Here's the new feed link, subscribe to it in your calendar app: %v\nAnyone with the link can see the meatball days, so keep it secret. I won't be able to show it to you again.": "Aquí tienes el nuevo enlace del calendario, suscríbete a él desde tu aplicación de calendario: %v\nCualquiera con el enlace puede ver los días de albóndiga, así que mantenlo en secreto. No podré volver a enseñártelo.",
+		"There's no feed link to revoke.":    "No hay ningún enlace de calendario que revocar.",
+		"Failed to revoke feed link: %v":     "No he podido revocar el enlace del calendario: %v",
+		"The feed link will no longer work.": "El enlace del calendario ya no funcionará.",
+		"Failed to export meatball days: %v": "No he podido exportar los días de albóndiga: %v",
+		"Here are this server's meatball days. Import the file into your calendar app to never miss one.": "Aquí tienes los días de albóndiga de este servidor. Importa el archivo en tu aplicación de calendario para no perderte ninguno.",
+		"Meatball days in %v %v": "Días de albóndiga en %v de %v",
+		"Meatball days in %v":    "Días de albóndiga en %v",
+		"%v's meatball day":      "Día de albóndiga de %v",
+		"%v more":                "%v más",
+		"\n…and %v more day.":    "\n…y %v día más.",
+		"\n…and %v more days.":   "\n…y %v días más.",
+
+		// importing and exporting
+		"I couldn't read %v: %v": "No he podido leer %v: %v",
+		"%v has %v rows I can import and %v with problems. Check the attached preview before confirming.": "%v tiene %v filas que puedo importar y %v con problemas. Revisa la vista previa adjunta antes de confirmar.",
+		"Failed to prepare import: %v":                                                            "No he podido preparar la importación: %v",
+		"This import has expired. Run the command again to start over.":                           "Esta importación ha caducado. Vuelve a usar el comando para empezar de nuevo.",
+		"Cancelled the import of %v.":                                                             "He cancelado la importación de %v.",
+		"Imported %v meatball days from %v, %v rows failed. The attached report has the details.": "He importado %v días de albóndiga de %v, han fallado %v filas. El informe adjunto tiene los detalles.",
+		"Failed to export server data: %v":                                                        "No he podido exportar los datos del servidor: %v",
+		"Here's everything I know about this server.":                                             "Aquí tienes todo lo que sé de este servidor.",
+		"the file is bigger than %v bytes":                                                        "el archivo ocupa más de %v bytes",
+		"downloading the file failed: %v":                                                         "no se ha podido descargar el archivo: %v",
+		"only .csv and .json files can be imported":                                               "solo se pueden importar archivos .csv y .json",
+		"expected at least a user and a date":                                                     "se esperaba al menos un usuario y una fecha",
+		"%q isn't a date in %v or YYYY-MM-DD format":                                              "%q no es una fecha en formato %v o YYYY-MM-DD",
+		"%q isn't a year":                                                                         "%q no es un año",
+		"%v isn't a valid year for a meatball day on %v":                                          "%v no es un año válido para un día de albóndiga el %v",
+		"%q matches more than one member, use their ID instead":                                   "%q coincide con más de un miembro, usa su ID",
+		"%q isn't a member of this server":                                                        "%q no es miembro de este servidor",
+		"%v already appears on line %v":                                                           "%v ya aparece en la línea %v",
+		"line %v: failed: %v":                                                                     "línea %v: error: %v",
+		"line %v: will import %v (%v) as %v":                                                      "línea %v: importaré a %v (%v) con el %v",
+		"line %v: imported %v (%v) as %v":                                                         "línea %v: he importado a %v (%v) con el %v",
+
+		// upcoming meatball days
+		"I haven't planned the next role check here yet. Try again in a moment.": "Todavía no he planificado la próxima comprobación de roles aquí. Inténtalo de nuevo en un momento.",
+		"I'll next check meatball roles here at %v, %v.":                         "La próxima vez que compruebe los roles aquí será el %v, %v.",
+		"Failed to get next meatball day: %v":                                    "No he podido obtener el próximo día de albóndiga: %v",
+		"There are no meatball days registered yet.":                             "Todavía no hay días de albóndiga registrados.",
+		"Today is %v's meatball day!":                                            "¡Hoy es el día de albóndiga de %v!",
+		"The next meatball day is %v's on %v, %v.":                               "El próximo día de albóndiga es el de %v, el %v, %v.",
+		"I can't list a negative number of meatball days.":                       "No puedo mostrar un número negativo de días de albóndiga.",
+		"There are no upcoming meatball days.":                                   "No hay próximos días de albóndiga.",
+		"**Upcoming meatball days** (page %v of %v):\n%v":                        "**Próximos días de albóndiga** (página %v de %v):\n%v",
+
+		// announcements
+		"It's %v's meatball day! Congratulations.":                                      "¡Es el día de albóndiga de %v! Felicidades.",
+		"It's meatball day for %v! Congratulations to all of you.":                      "¡Es el día de albóndiga de %v! Felicidades a todos.",
+		"I missed the meatball day of %v on %v! Belated congratulations to all of you.": "¡Me perdí el día de albóndiga de %v el %v! Felicidades con retraso a todos.",
+		"(This one's belated, I missed it while I was offline.)":                        "(Con retraso, me lo perdí mientras estaba desconectado.)",
+		"I missed %v's meatball day on %v! Belated congratulations.":                    "¡Me perdí el día de albóndiga de %v el %v! Felicidades con retraso.",
+	},
+}
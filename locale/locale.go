@@ -0,0 +1,179 @@
+// Package locale translates casper's messages into the languages of the
+// communities it is used in.
+//
+// Messages are written in English throughout casper, and the English text of a
+// message doubles as its key in the other languages' catalogs. Any message
+// missing from a catalog is left in English.
+package locale
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// English is the language messages are written in.
+const English = "en"
+
+// language holds everything needed to write messages in a language.
+type language struct {
+	name     string            // the language's name for itself
+	messages map[string]string // translations of English format strings
+	months   [12]string
+	weekdays [7]string // starting with sunday, like time.Weekday
+
+	// format of a month and day, given the month's name and the day number.
+	date string
+
+	ordinal func(n int) string
+}
+
+var languages = map[string]*language{
+	English: {
+		name:     "English",
+		months:   [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		date:     "%[1]v %[2]v",
+		ordinal:  humanize.Ordinal,
+	},
+	"es": spanish,
+	"de": german,
+}
+
+// Languages returns the codes of all supported languages, English first.
+func Languages() []string {
+	var codes []string
+	for code := range languages {
+		if code != English {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	return append([]string{English}, codes...)
+}
+
+// Name returns the given language's name for itself.
+func Name(code string) string {
+	if language, ok := languages[code]; ok {
+		return language.name
+	}
+	return code
+}
+
+// Supported returns true if messages can be written in the given language.
+func Supported(code string) bool {
+	_, ok := languages[code]
+	return ok
+}
+
+// Match returns the supported language for a Discord locale such as en-GB or
+// es-ES, if there is one.
+func Match(discordLocale string) (string, bool) {
+	code := strings.ToLower(strings.SplitN(discordLocale, "-", 2)[0])
+	return code, Supported(code)
+}
+
+// Printer writes messages in a particular language.
+type Printer struct {
+	code     string
+	language *language
+}
+
+// NewPrinter returns a printer for the given language, falling back to English
+// if it isn't supported.
+func NewPrinter(code string) Printer {
+	if language, ok := languages[code]; ok {
+		return Printer{code: code, language: language}
+	}
+	return Printer{code: English, language: languages[English]}
+}
+
+// Language returns the code of the language the printer writes in.
+func (p Printer) Language() string {
+	return p.code
+}
+
+// Sprintf translates the given English format string and formats it with the
+// given arguments.
+func (p Printer) Sprintf(format string, args ...interface{}) string {
+	if translated, ok := p.language.messages[format]; ok {
+		format = translated
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Plural translates and formats one if n is 1, and other otherwise.
+func (p Printer) Plural(n int, one string, other string, args ...interface{}) string {
+	if n == 1 {
+		return p.Sprintf(one, args...)
+	}
+	return p.Sprintf(other, args...)
+}
+
+// Month returns the name of the given month.
+func (p Printer) Month(month time.Month) string {
+	return p.language.months[month-1]
+}
+
+// Weekday returns the name of the given day of the week.
+func (p Printer) Weekday(weekday time.Weekday) string {
+	return p.language.weekdays[weekday]
+}
+
+// Date returns the month and day of the given date, e.g. January 2.
+func (p Printer) Date(date time.Time) string {
+	return fmt.Sprintf(p.language.date, p.Month(date.Month()), date.Day())
+}
+
+// Ordinal returns the given number as an ordinal, e.g. 21st.
+func (p Printer) Ordinal(n int) string {
+	return p.language.ordinal(n)
+}
+
+// List joins items into a list, e.g. "a, b and c".
+func (p Printer) List(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return p.Sprintf(
+		"%v and %v",
+		strings.Join(items[:len(items)-1], ", "),
+		items[len(items)-1],
+	)
+}
+
+// RelTime describes how long before or after now t is, e.g. 3 days from now.
+func (p Printer) RelTime(t time.Time, now time.Time) string {
+	if p.code == English {
+		return humanize.RelTime(t, now, "ago", "from now")
+	}
+
+	duration := t.Sub(now)
+	future := duration >= 0
+	if !future {
+		duration = -duration
+	}
+
+	var amount string
+	switch {
+	case duration < time.Minute:
+		return p.Sprintf("now")
+	case duration < time.Hour:
+		minutes := int(duration / time.Minute)
+		amount = p.Plural(minutes, "%v minute", "%v minutes", minutes)
+	case duration < 24*time.Hour:
+		hours := int(duration / time.Hour)
+		amount = p.Plural(hours, "%v hour", "%v hours", hours)
+	default:
+		days := int(duration / (24 * time.Hour))
+		amount = p.Plural(days, "%v day", "%v days", days)
+	}
+
+	if future {
+		return p.Sprintf("%v from now", amount)
+	}
+	return p.Sprintf("%v ago", amount)
+}
@@ -3,11 +3,19 @@ package main
 import (
 	"casper/bot"
 	"casper/dal"
+	"casper/export"
 	"flag"
 	"fmt"
+	"log"
+	"math/rand"
 	"os"
 	"os/signal"
 	"time"
+
+	"gorm.io/gorm"
+
+	// embed the time zone database, the docker image doesn't ship one.
+	_ "time/tzdata"
 )
 
 var (
@@ -26,36 +34,83 @@ var (
 		"casper.db",
 		"SQLite database file path.",
 	)
+	httpAddr = flag.String(
+		"httpAddr",
+		"",
+		"Address to serve calendar feeds on, e.g. :8080. If not set, feeds are disabled.",
+	)
+	feedURL = flag.String(
+		"feedURL",
+		"",
+		"Public URL the feed server is reachable at, used in feed links. Required with -httpAddr.",
+	)
+	exportFormat = flag.String(
+		"exportFormat",
+		export.FormatJSON,
+		"Format used by the export subcommand, json or csv.",
+	)
 )
 
+// exporting is true if casper was run as `casper [flags] export GUILD_ID`, in
+// which case it writes the guild's data to stdout instead of running the bot.
+var exporting bool
+
 func init() {
 	flag.Parse()
 
 	okay := true
 
-	if *botToken == "" {
-		fmt.Println("-token must be provided.")
-		okay = false
+	exporting = flag.Arg(0) == "export"
+
+	if exporting {
+		if flag.NArg() != 2 {
+			fmt.Println("export needs exactly one guild ID.")
+			okay = false
+		}
+
+		if !export.ValidFormat(*exportFormat) {
+			fmt.Println("-exportFormat must be json or csv.")
+			okay = false
+		}
+	} else {
+		if *botToken == "" {
+			fmt.Println("-token must be provided.")
+			okay = false
+		}
+
+		if *httpAddr != "" && *feedURL == "" {
+			fmt.Println("-feedURL must be provided to serve feeds.")
+			okay = false
+		}
 	}
 
 	if !okay {
 		fmt.Println()
-		flag.Usage()
+		fmt.Println("Usage: casper [flags] [export GUILD_ID]")
+		flag.PrintDefaults()
 		os.Exit(1)
 	}
 }
 
 func main() {
+	// announcement templates are picked at random.
+	rand.Seed(time.Now().UnixNano())
+
+	if exporting {
+		// exporting only reads, so the database is left exactly as it is.
+		exportGuild(flag.Arg(1), dal.OpenDB(*dbPath))
+		return
+	}
+
 	db := dal.InitDB(*dbPath)
 
-	casper := bot.New(*botToken, *guildID, db)
-	defer casper.Shutdown(*guildID)
+	casper := bot.New(*botToken, *guildID, *httpAddr, *feedURL, db)
+	defer casper.Shutdown()
 
 	casper.CheckRoles()
 
-	ticker := time.NewTicker(1 * 24 * 60 * 60 * time.Second)
 	done := make(chan bool)
-	go casper.RoleChecker(ticker, done)
+	go casper.RunScheduler(done)
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
@@ -64,3 +119,16 @@ func main() {
 	// signal the role checker to stop
 	done <- true
 }
+
+// Writes all of the given guild's data to stdout.
+func exportGuild(guildID string, db *gorm.DB) {
+	guild, err := export.Load(guildID, db)
+	if err != nil {
+		log.Fatalf("Failed to export guild %v: %v", guildID, err)
+	}
+
+	err = guild.Write(os.Stdout, *exportFormat)
+	if err != nil {
+		log.Fatalf("Failed to write export of guild %v: %v", guildID, err)
+	}
+}
@@ -1,14 +1,140 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // MeatballDay represents a user's birth day and month.
 type MeatballDay struct {
 	gorm.Model
-	GuildID string `gorm:"index:idx_unique_guild_member,unique"`
-	UserID  string `gorm:"index:idx_unique_guild_member,unique"`
-	Month   uint
-	Day     uint
+	GuildID  string `gorm:"index:idx_unique_guild_member,unique"`
+	UserID   string `gorm:"index:idx_unique_guild_member,unique"`
+	Month    uint
+	Day      uint
+	Year     uint // 0 if the user hasn't given one
+	TimeZone string
+	ShowAge  bool
+	Private  bool // left out of calendar exports if true
+}
+
+// Location returns the time zone the meatball day should be celebrated in,
+// falling back to the guild's time zone if the user hasn't set one.
+func (meatballDay MeatballDay) Location(guildSettings GuildSettings) *time.Location {
+	if meatballDay.TimeZone != "" {
+		if loc, err := LoadTimeZone(meatballDay.TimeZone); err == nil {
+			return loc
+		}
+	}
+	return guildSettings.Location()
+}
+
+// Occurrence returns the start of the day the meatball day is celebrated on in
+// the given year, taking the guild's leap day policy into account.
+// Returns false if the meatball day isn't celebrated at all that year.
+func (meatballDay MeatballDay) Occurrence(
+	year int,
+	guildSettings GuildSettings,
+) (time.Time, bool) {
+	date := time.Date(
+		year,
+		time.Month(meatballDay.Month),
+		int(meatballDay.Day),
+		0, 0, 0, 0,
+		meatballDay.Location(guildSettings),
+	)
+
+	if date.Month() == time.Month(meatballDay.Month) {
+		return date, true
+	}
+
+	// only 29th february can spill over into the next month.
+	switch guildSettings.LeapDayPolicy {
+	case LeapDayMarch1:
+		return date, true
+	case LeapDaySkip:
+		return time.Time{}, false
+	default:
+		return date.AddDate(0, 0, -1), true
+	}
+}
+
+// NextOccurrence returns the start of the first day the meatball day is
+// celebrated on after the given instant.
+func (meatballDay MeatballDay) NextOccurrence(
+	t time.Time,
+	guildSettings GuildSettings,
+) time.Time {
+	year := t.In(meatballDay.Location(guildSettings)).Year()
+
+	for ; ; year++ {
+		if date, ok := meatballDay.Occurrence(year, guildSettings); ok && date.After(t) {
+			return date
+		}
+	}
+}
+
+// UpcomingOccurrence returns the start of the first day the meatball day is
+// celebrated on that hasn't finished by the given instant.
+func (meatballDay MeatballDay) UpcomingOccurrence(
+	t time.Time,
+	guildSettings GuildSettings,
+) time.Time {
+	year, month, day := t.In(meatballDay.Location(guildSettings)).Date()
+	startOfDay := time.Date(
+		year, month, day,
+		0, 0, 0, 0,
+		meatballDay.Location(guildSettings),
+	)
+	return meatballDay.NextOccurrence(startOfDay.Add(-time.Nanosecond), guildSettings)
+}
+
+// IsOn returns true if the given instant falls on the day the meatball day is
+// celebrated, in the meatball day's own time zone.
+func (meatballDay MeatballDay) IsOn(t time.Time, guildSettings GuildSettings) bool {
+	local := t.In(meatballDay.Location(guildSettings))
+
+	date, ok := meatballDay.Occurrence(local.Year(), guildSettings)
+	if !ok {
+		return false
+	}
+
+	return date.Month() == local.Month() && date.Day() == local.Day()
+}
+
+// AgeIn returns how old the user turns on their meatball day in the given year.
+// Only meaningful if the user has given their birth year.
+func (meatballDay MeatballDay) AgeIn(year int) int {
+	return year - int(meatballDay.Year)
+}
+
+// Age returns how old the user is at the given instant. The age goes up on the
+// day the meatball day is celebrated, so it always agrees with the age given
+// in announcements. Only meaningful if the user has given their birth year.
+func (meatballDay MeatballDay) Age(t time.Time, guildSettings GuildSettings) int {
+	local := t.In(meatballDay.Location(guildSettings))
+	age := meatballDay.AgeIn(local.Year())
+
+	birthday, ok := meatballDay.Occurrence(local.Year(), guildSettings)
+	if !ok {
+		// a skipped 29th february still counts once it's passed. time.Date
+		// normalises it to 1st march.
+		birthday = time.Date(
+			local.Year(),
+			time.Month(meatballDay.Month),
+			int(meatballDay.Day),
+			0, 0, 0, 0,
+			local.Location(),
+		)
+	}
+	if local.Before(birthday) {
+		age--
+	}
+
+	return age
 }
 
 // MeatballRole maps guild IDs to their respective meatball day roles.
@@ -24,3 +150,237 @@ type MeatballChannel struct {
 	GuildID   string `gorm:"uniqueIndex"`
 	ChannelID string
 }
+
+// Policies for meatball days that were missed while casper was offline.
+const (
+	MissedDayBelated = "belated"
+	MissedDaySkip    = "skip"
+)
+
+// Policies for 29th february meatball days in non-leap years.
+// LeapDayFebruary28 is the default.
+const (
+	LeapDayFebruary28 = "feb28"
+	LeapDayMarch1     = "mar1"
+	LeapDaySkip       = "skip"
+)
+
+// Schedules for digests of upcoming meatball days.
+// Digests are off by default.
+const (
+	DigestWeekly  = "weekly"
+	DigestMonthly = "monthly"
+)
+
+// Orders to read numeric dates like 2/1 in.
+// DateOrderMonthDay is the default.
+const (
+	DateOrderMonthDay = "md"
+	DateOrderDayMonth = "dm"
+)
+
+// Styles of meatball day announcement.
+// AnnouncementPlain is the default.
+const (
+	AnnouncementPlain = "plain"
+	AnnouncementEmbed = "embed"
+)
+
+// DefaultAnnouncementColor is used for embed announcements if the guild hasn't
+// chosen a color.
+const DefaultAnnouncementColor = 0xe67e22
+
+// What happens to a wishes thread once the meatball day it was started for is
+// over. WishesArchive is the default.
+const (
+	WishesArchive = "archive"
+	WishesLock    = "lock"
+	WishesKeep    = "keep"
+)
+
+// GuildSettings holds miscellaneous per-guild configuration.
+type GuildSettings struct {
+	gorm.Model
+	GuildID         string `gorm:"uniqueIndex"`
+	TimeZone        string
+	MissedDayPolicy string
+	LeapDayPolicy   string
+
+	// comma separated numbers of days before a meatball day to send reminders.
+	ReminderDays      string
+	ReminderChannelID string
+
+	DigestSchedule string
+	DigestWeekday  int // only used by weekly digests
+	DigestDays     int // 0 to cover the time until the next digest
+
+	DateOrder string
+	Language  string // empty to follow the guild's discord locale
+
+	AnnouncementStyle    string
+	AnnouncementColor    *int   // nil for DefaultAnnouncementColor
+	AnnouncementImageURL string // only used by embed announcements
+
+	// announce everyone who shares a meatball day in a single message.
+	CombineAnnouncements bool
+
+	// start a thread on each announcement for members to leave wishes in.
+	WishesThreads    bool
+	WishesThreadName string // a template, empty for the default name
+	WishesPolicy     string
+}
+
+// Location returns the guild's default time zone, or the host's time zone if
+// the guild hasn't set one.
+func (guildSettings GuildSettings) Location() *time.Location {
+	if guildSettings.TimeZone != "" {
+		if loc, err := LoadTimeZone(guildSettings.TimeZone); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
+// SkipsMissedDays returns true if the guild doesn't want belated announcements
+// for meatball days missed while casper was offline.
+func (guildSettings GuildSettings) SkipsMissedDays() bool {
+	return guildSettings.MissedDayPolicy == MissedDaySkip
+}
+
+// DayFirst returns true if the guild writes numeric dates with the day before
+// the month.
+func (guildSettings GuildSettings) DayFirst() bool {
+	return guildSettings.DateOrder == DateOrderDayMonth
+}
+
+// EmbedsAnnouncements returns true if the guild wants meatball days announced
+// in an embed rather than a plain message.
+func (guildSettings GuildSettings) EmbedsAnnouncements() bool {
+	return guildSettings.AnnouncementStyle == AnnouncementEmbed
+}
+
+// EmbedColor returns the color of the guild's embed announcements.
+func (guildSettings GuildSettings) EmbedColor() int {
+	if guildSettings.AnnouncementColor != nil {
+		return *guildSettings.AnnouncementColor
+	}
+	return DefaultAnnouncementColor
+}
+
+// Reminders returns the numbers of days before a meatball day that the guild
+// wants reminders to be sent.
+func (guildSettings GuildSettings) Reminders() []int {
+	var reminders []int
+	for _, field := range strings.Split(guildSettings.ReminderDays, ",") {
+		if days, err := strconv.Atoi(strings.TrimSpace(field)); err == nil && days > 0 {
+			reminders = append(reminders, days)
+		}
+	}
+	return reminders
+}
+
+// DigestPeriod returns the number of days a digest posted on the given date
+// should cover, or 0 if no digest is due on that date.
+func (guildSettings GuildSettings) DigestPeriod(date time.Time) int {
+	var period int
+
+	switch guildSettings.DigestSchedule {
+	case DigestWeekly:
+		if date.Weekday() != time.Weekday(guildSettings.DigestWeekday) {
+			return 0
+		}
+		period = 7
+	case DigestMonthly:
+		if date.Day() != 1 {
+			return 0
+		}
+		period = date.AddDate(0, 1, -1).Day()
+	default:
+		return 0
+	}
+
+	if guildSettings.DigestDays > 0 {
+		period = guildSettings.DigestDays
+	}
+
+	return period
+}
+
+// RoleCheck records when a guild's meatball roles were last checked.
+type RoleCheck struct {
+	gorm.Model
+	GuildID   string `gorm:"uniqueIndex"`
+	CheckedAt time.Time
+}
+
+// Announcement records that a user's meatball day has been announced in a
+// guild for a particular year.
+type Announcement struct {
+	gorm.Model
+	GuildID string `gorm:"index:idx_unique_announcement,unique"`
+	UserID  string `gorm:"index:idx_unique_announcement,unique"`
+	Year    int    `gorm:"index:idx_unique_announcement,unique"`
+}
+
+// MilestoneTemplate is a special announcement a guild uses for meatball days on
+// which a user reaches a particular age.
+type MilestoneTemplate struct {
+	gorm.Model
+	GuildID  string `gorm:"index:idx_unique_guild_milestone,unique"`
+	Age      uint   `gorm:"index:idx_unique_guild_milestone,unique"`
+	Template string
+}
+
+// AnnouncementTemplate is one of the announcements a guild picks from at random
+// when it's someone's meatball day.
+type AnnouncementTemplate struct {
+	gorm.Model
+	GuildID  string `gorm:"index"`
+	Template string
+	Combined bool // used to announce several members at once
+}
+
+// WishesThread records the thread started on a member's announcement, so that it
+// can be archived once their meatball day is over. Members announced together
+// share a thread.
+type WishesThread struct {
+	gorm.Model
+	GuildID  string `gorm:"index:idx_unique_wishes_thread,unique"`
+	UserID   string `gorm:"index:idx_unique_wishes_thread,unique"`
+	ThreadID string `gorm:"index"`
+}
+
+// MeatballFollow records that a user wants to be reminded of another user's
+// meatball day by DM.
+type MeatballFollow struct {
+	gorm.Model
+	GuildID        string `gorm:"index:idx_unique_follow,unique"`
+	UserID         string `gorm:"index:idx_unique_follow,unique"`
+	FollowedUserID string `gorm:"index:idx_unique_follow,unique"`
+}
+
+// SentReminder records that a reminder of a user's meatball day has been sent
+// to a recipient. RecipientID is empty for reminders sent to a guild channel.
+type SentReminder struct {
+	gorm.Model
+	GuildID     string `gorm:"index:idx_unique_reminder,unique"`
+	UserID      string `gorm:"index:idx_unique_reminder,unique"`
+	Year        int    `gorm:"index:idx_unique_reminder,unique"`
+	DaysBefore  int    `gorm:"index:idx_unique_reminder,unique"`
+	RecipientID string `gorm:"index:idx_unique_reminder,unique"`
+}
+
+// SentDigest records that a guild's digest has been posted for the given date.
+type SentDigest struct {
+	gorm.Model
+	GuildID string `gorm:"index:idx_unique_digest,unique"`
+	Date    string `gorm:"index:idx_unique_digest,unique"`
+}
+
+// FeedToken grants access to a guild's meatball day calendar feed.
+// Only a hash of the token itself is stored.
+type FeedToken struct {
+	gorm.Model
+	GuildID   string `gorm:"uniqueIndex"`
+	TokenHash string `gorm:"uniqueIndex"`
+}
@@ -0,0 +1,202 @@
+package models
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // the tests don't depend on the host's zone database
+)
+
+// leapDay is a meatball day on 29th february.
+var leapDay = MeatballDay{Month: 2, Day: 29, Year: 2000}
+
+func mustLoadTimeZone(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := LoadTimeZone(name)
+	if err != nil {
+		t.Fatalf("LoadTimeZone(%q): %v", name, err)
+	}
+	return loc
+}
+
+func TestOccurrence(t *testing.T) {
+	tests := []struct {
+		name        string
+		meatballDay MeatballDay
+		year        int
+		policy      string
+		wantMonth   time.Month
+		wantDay     int
+		wantOK      bool
+	}{
+		{"ordinary day", MeatballDay{Month: 6, Day: 15}, 2023, "", time.June, 15, true},
+		{"leap day in a leap year", leapDay, 2024, LeapDaySkip, time.February, 29, true},
+		{"default policy is feb28", leapDay, 2023, "", time.February, 28, true},
+		{"feb28 policy", leapDay, 2023, LeapDayFebruary28, time.February, 28, true},
+		{"mar1 policy", leapDay, 2023, LeapDayMarch1, time.March, 1, true},
+		{"skip policy", leapDay, 2023, LeapDaySkip, 0, 0, false},
+		{"century years aren't leap years", leapDay, 2100, LeapDayMarch1, time.March, 1, true},
+		{"every 400 years is a leap year", leapDay, 2000, LeapDaySkip, time.February, 29, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			guildSettings := GuildSettings{TimeZone: "UTC", LeapDayPolicy: test.policy}
+
+			date, ok := test.meatballDay.Occurrence(test.year, guildSettings)
+			if ok != test.wantOK {
+				t.Fatalf("Occurrence(%v) ok = %v, want %v", test.year, ok, test.wantOK)
+			}
+			if !ok {
+				return
+			}
+
+			if date.Year() != test.year || date.Month() != test.wantMonth || date.Day() != test.wantDay {
+				t.Errorf(
+					"Occurrence(%v) = %v, want %v-%02d-%02d",
+					test.year, date.Format("2006-01-02"), test.year, int(test.wantMonth), test.wantDay,
+				)
+			}
+		})
+	}
+}
+
+func TestOccurrenceTimeZone(t *testing.T) {
+	guildSettings := GuildSettings{TimeZone: "Europe/London"}
+
+	tests := []struct {
+		name        string
+		meatballDay MeatballDay
+		want        *time.Location
+	}{
+		{"guild's time zone", MeatballDay{Month: 1, Day: 1}, mustLoadTimeZone(t, "Europe/London")},
+		{"member's time zone", MeatballDay{Month: 1, Day: 1, TimeZone: "Pacific/Kiritimati"}, mustLoadTimeZone(t, "Pacific/Kiritimati")},
+		{"unknown time zone falls back", MeatballDay{Month: 1, Day: 1, TimeZone: "Nowhere/Nowhere"}, mustLoadTimeZone(t, "Europe/London")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			date, _ := test.meatballDay.Occurrence(2023, guildSettings)
+			want := time.Date(2023, time.January, 1, 0, 0, 0, 0, test.want)
+			if !date.Equal(want) {
+				t.Errorf("Occurrence(2023) = %v, want %v", date, want)
+			}
+		})
+	}
+}
+
+func TestIsOn(t *testing.T) {
+	tests := []struct {
+		name        string
+		meatballDay MeatballDay
+		policy      string
+		instant     time.Time
+		want        bool
+	}{
+		{"on the day", MeatballDay{Month: 6, Day: 15}, "", time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC), true},
+		{"day before", MeatballDay{Month: 6, Day: 15}, "", time.Date(2023, 6, 14, 23, 59, 0, 0, time.UTC), false},
+		{"day after", MeatballDay{Month: 6, Day: 15}, "", time.Date(2023, 6, 16, 0, 0, 0, 0, time.UTC), false},
+		{"leap day in a leap year", leapDay, LeapDaySkip, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), true},
+		{"feb28 on 28th february", leapDay, LeapDayFebruary28, time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC), true},
+		{"feb28 on 1st march", leapDay, LeapDayFebruary28, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"feb28 in a leap year", leapDay, LeapDayFebruary28, time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), false},
+		{"mar1 on 1st march", leapDay, LeapDayMarch1, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), true},
+		{"mar1 on 28th february", leapDay, LeapDayMarch1, time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC), false},
+		{"mar1 in a leap year", leapDay, LeapDayMarch1, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"skip on 28th february", leapDay, LeapDaySkip, time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC), false},
+		{"skip on 1st march", leapDay, LeapDaySkip, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{
+			"already the day in the member's time zone",
+			MeatballDay{Month: 6, Day: 15, TimeZone: "Pacific/Kiritimati"}, "",
+			time.Date(2023, 6, 14, 10, 0, 0, 0, time.UTC), true,
+		},
+		{
+			"not yet the day in the member's time zone",
+			MeatballDay{Month: 6, Day: 15, TimeZone: "Pacific/Pago_Pago"}, "",
+			time.Date(2023, 6, 15, 10, 0, 0, 0, time.UTC), false,
+		},
+		{
+			"new year's day crosses the year in the member's time zone",
+			MeatballDay{Month: 1, Day: 1, TimeZone: "Pacific/Kiritimati"}, "",
+			time.Date(2022, 12, 31, 10, 0, 0, 0, time.UTC), true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			guildSettings := GuildSettings{TimeZone: "UTC", LeapDayPolicy: test.policy}
+
+			if got := test.meatballDay.IsOn(test.instant, guildSettings); got != test.want {
+				t.Errorf("IsOn(%v) = %v, want %v", test.instant, got, test.want)
+			}
+		})
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		instant time.Time
+		want    time.Time
+	}{
+		{"skip waits for the next leap year", LeapDaySkip, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"feb28 this year", LeapDayFebruary28, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"mar1 this year", LeapDayMarch1, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"strictly after the instant", LeapDayMarch1, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			guildSettings := GuildSettings{TimeZone: "UTC", LeapDayPolicy: test.policy}
+
+			if got := leapDay.NextOccurrence(test.instant, guildSettings); !got.Equal(test.want) {
+				t.Errorf("NextOccurrence(%v) = %v, want %v", test.instant, got, test.want)
+			}
+		})
+	}
+}
+
+func TestAge(t *testing.T) {
+	tests := []struct {
+		name        string
+		meatballDay MeatballDay
+		policy      string
+		instant     time.Time
+		want        int
+	}{
+		{"day before", MeatballDay{Month: 6, Day: 15, Year: 1990}, "", time.Date(2023, 6, 14, 0, 0, 0, 0, time.UTC), 32},
+		{"on the day", MeatballDay{Month: 6, Day: 15, Year: 1990}, "", time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC), 33},
+		{"leap year", leapDay, LeapDaySkip, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), 24},
+		{"feb28 before", leapDay, LeapDayFebruary28, time.Date(2023, 2, 27, 0, 0, 0, 0, time.UTC), 22},
+		{"feb28 on 28th february", leapDay, LeapDayFebruary28, time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC), 23},
+		{"mar1 on 28th february", leapDay, LeapDayMarch1, time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC), 22},
+		{"mar1 on 1st march", leapDay, LeapDayMarch1, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), 23},
+		{"skip on 28th february", leapDay, LeapDaySkip, time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC), 22},
+		{"skip on 1st march", leapDay, LeapDaySkip, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), 23},
+		{
+			"member's time zone",
+			MeatballDay{Month: 6, Day: 15, Year: 1990, TimeZone: "Pacific/Kiritimati"}, "",
+			time.Date(2023, 6, 14, 10, 0, 0, 0, time.UTC), 33,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			guildSettings := GuildSettings{TimeZone: "UTC", LeapDayPolicy: test.policy}
+
+			got := test.meatballDay.Age(test.instant, guildSettings)
+			if got != test.want {
+				t.Errorf("Age(%v) = %v, want %v", test.instant, got, test.want)
+			}
+
+			// on the day itself, the age must match the one announced.
+			if test.meatballDay.IsOn(test.instant, guildSettings) {
+				year := test.instant.In(test.meatballDay.Location(guildSettings)).Year()
+				if announced := test.meatballDay.AgeIn(year); announced != got {
+					t.Errorf("Age(%v) = %v, but AgeIn(%v) = %v", test.instant, got, year, announced)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoadTimeZone loads the IANA time zone with the given name.
+// Unlike time.LoadLocation, the empty string and "Local" are rejected since
+// they refer to whatever zone the host happens to be running in.
+func LoadTimeZone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return time.LoadLocation(name)
+}